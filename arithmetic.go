@@ -0,0 +1,128 @@
+package bitwisebytes
+
+import "math/bits"
+
+// ------------------------------------------------------------------
+//                   Arithmetic on wide registers
+// ------------------------------------------------------------------
+// The operands are unsigned little endian slices of bytes processed as 64
+// bits words, like ShiftLeft does. Operations are modulo 2^widthBits on the
+// widthBits least significant bits, the bits above them are left untouched.
+// A widthBits of 0 uses the whole slice.
+
+// arithWidth resolves a widthBits of 0 and validates widthBits and the
+// operand lengths against the size bytes of the result
+func arithWidth(op string, widthBits uint, size int, operands ...[]byte) (uint, error) {
+	if widthBits == 0 {
+		widthBits = uint(size) * 8
+	}
+	for _, operand := range operands {
+		switch {
+		case len(operand) < size:
+			return 0, fieldError(op, 0, widthBits, ErrBufferTooShort)
+		case len(operand) > size:
+			return 0, fieldError(op, 0, widthBits, ErrBufferTooLong)
+		}
+	}
+	if widthBits > uint(size)*8 {
+		return 0, fieldError(op, 0, widthBits, ErrBufferTooShort)
+	}
+	return widthBits, nil
+}
+
+// topWordMask returns the mask of the bits of word i below widthBits
+func topWordMask(i int, widthBits uint) uint64 {
+	if used := widthBits - uint(i)*64; used < 64 {
+		return 1<<used - 1
+	}
+	return ^uint64(0)
+}
+
+// wordArith stores x + y + carryIn, or x - y - carryIn when sub is set, in
+// the words of dst holding the widthBits least significant bits and returns
+// the carry, or borrow, out of bit widthBits-1. A nil x or y reads as zero.
+// Word i of x and y is read before word i of dst is written so dst may be
+// one of the operands.
+func wordArith(dst, x, y []byte, widthBits uint, carryIn uint64, sub bool) (carry bool) {
+	c := carryIn
+	for i := 0; uint(i)*64 < widthBits; i++ {
+		mask := topWordMask(i, widthBits)
+		var word uint64
+		if sub {
+			word, c = bits.Sub64(loadWord64(x, i)&mask, loadWord64(y, i)&mask, c)
+		} else {
+			word, c = bits.Add64(loadWord64(x, i)&mask, loadWord64(y, i)&mask, c)
+		}
+		if mask != ^uint64(0) {
+			// The carry, or borrow, out of a partial word is the bit above it
+			c = word >> bits.Len64(mask) & 1
+		}
+		storeWord64(dst, i, loadWord64(dst, i)&^mask|word&mask)
+	}
+	return c == 1
+}
+
+// Add stores a + b in dst and returns the carry out of bit widthBits-1
+func Add(dst, a, b []byte, widthBits uint) (carry bool, err error) {
+	if widthBits, err = arithWidth("Add", widthBits, len(dst), a, b); err != nil {
+		return false, err
+	}
+	return wordArith(dst, a, b, widthBits, 0, false), err
+}
+
+// Sub stores a - b in dst and returns the borrow into bit widthBits-1, set
+// when b is greater than a
+func Sub(dst, a, b []byte, widthBits uint) (borrow bool, err error) {
+	if widthBits, err = arithWidth("Sub", widthBits, len(dst), a, b); err != nil {
+		return false, err
+	}
+	return wordArith(dst, a, b, widthBits, 0, true), err
+}
+
+// Inc adds one to inputOutput and returns the carry out of bit widthBits-1,
+// set when the register wraps to zero
+func Inc(inputOutput []byte, widthBits uint) (carry bool, err error) {
+	if widthBits, err = arithWidth("Inc", widthBits, len(inputOutput)); err != nil {
+		return false, err
+	}
+	return wordArith(inputOutput, inputOutput, nil, widthBits, 1, false), err
+}
+
+// Dec subtracts one from inputOutput and returns the borrow, set when the
+// register wraps from zero to all ones
+func Dec(inputOutput []byte, widthBits uint) (borrow bool, err error) {
+	if widthBits, err = arithWidth("Dec", widthBits, len(inputOutput)); err != nil {
+		return false, err
+	}
+	return wordArith(inputOutput, inputOutput, nil, widthBits, 1, true), err
+}
+
+// Negate stores the two's complement of inputOutput, 0 - inputOutput, in
+// inputOutput
+func Negate(inputOutput []byte, widthBits uint) (err error) {
+	if widthBits, err = arithWidth("Negate", widthBits, len(inputOutput)); err != nil {
+		return err
+	}
+	wordArith(inputOutput, nil, inputOutput, widthBits, 0, true)
+	return err
+}
+
+// Compare returns -1, 0 or +1 when the widthBits least significant bits of
+// a are, as an unsigned value, less than, equal to or greater than those
+// of b
+func Compare(a, b []byte, widthBits uint) (result int, err error) {
+	if widthBits, err = arithWidth("Compare", widthBits, len(a), b); err != nil {
+		return 0, err
+	}
+	for i := int((widthBits+63)/64) - 1; i >= 0; i-- {
+		mask := topWordMask(i, widthBits)
+		x, y := loadWord64(a, i)&mask, loadWord64(b, i)&mask
+		switch {
+		case x < y:
+			return -1, err
+		case x > y:
+			return 1, err
+		}
+	}
+	return 0, err
+}
@@ -0,0 +1,105 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+// lowBits returns the widthBits least significant bits of a little endian
+// slice of bytes as a big.Int
+func lowBits(b []byte, widthBits uint) *big.Int {
+	x := bitwisebytes.ToBigInt(b, bitwisebytes.LittleEndian)
+	return x.And(x, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), widthBits), big.NewInt(1)))
+}
+
+func TestArithmetic(t *testing.T) {
+	for i := 0; i < testLooops*10; i++ {
+		size := rand.Intn(24) + 1
+		a := make([]byte, size)
+		b := make([]byte, size)
+		rand.Read(a)
+		rand.Read(b)
+		if rand.Intn(4) == 0 {
+			copy(b, a)
+		}
+		widthBits := uint(rand.Intn(size*8) + 1)
+		modulus := new(big.Int).Lsh(big.NewInt(1), widthBits)
+		x, y := lowBits(a, widthBits), lowBits(b, widthBits)
+
+		// The bits above widthBits must survive
+		checkHigh := func(name string, result, original []byte) {
+			high := append([]byte(nil), result...)
+			bitwisebytes.ClearRange(high, 0, widthBits)
+			expected := append([]byte(nil), original...)
+			bitwisebytes.ClearRange(expected, 0, widthBits)
+			if !bytes.Equal(high, expected) {
+				t.Fatalf("%s width:%d modified the bits above the register", name, widthBits)
+			}
+		}
+
+		dst := append([]byte(nil), a...)
+		carry, err := bitwisebytes.Add(dst, dst, b, widthBits)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		sum := new(big.Int).Add(x, y)
+		if lowBits(dst, widthBits).Cmp(new(big.Int).Mod(sum, modulus)) != 0 || carry != (sum.Cmp(modulus) >= 0) {
+			t.Fatalf("Add width:%d mistmatch: %X + %X = %X carry %v", widthBits, a, b, dst, carry)
+		}
+		checkHigh("Add", dst, a)
+
+		dst = make([]byte, size)
+		borrow, err := bitwisebytes.Sub(dst, a, b, widthBits)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		difference := new(big.Int).Sub(x, y)
+		if lowBits(dst, widthBits).Cmp(new(big.Int).Mod(difference, modulus)) != 0 || borrow != (difference.Sign() < 0) {
+			t.Fatalf("Sub width:%d mistmatch: %X - %X = %X borrow %v", widthBits, a, b, dst, borrow)
+		}
+
+		if r, _ := bitwisebytes.Compare(a, b, widthBits); r != x.Cmp(y) {
+			t.Fatalf("Compare width:%d mistmatch: %d != %d", widthBits, r, x.Cmp(y))
+		}
+
+		dst = append([]byte(nil), a...)
+		if err := bitwisebytes.Negate(dst, widthBits); err != nil {
+			t.Fatal(err.Error())
+		}
+		if lowBits(dst, widthBits).Cmp(new(big.Int).Mod(new(big.Int).Neg(x), modulus)) != 0 {
+			t.Fatalf("Negate width:%d mistmatch: %X -> %X", widthBits, a, dst)
+		}
+		checkHigh("Negate", dst, a)
+
+		dst = append([]byte(nil), a...)
+		carry, _ = bitwisebytes.Inc(dst, widthBits)
+		borrow, _ = bitwisebytes.Dec(dst, widthBits)
+		if !bytes.Equal(dst, a) || carry != borrow {
+			t.Fatalf("Inc/Dec width:%d mistmatch: %X -> %X", widthBits, a, dst)
+		}
+	}
+
+	// A 12 bits counter wraps without touching the nibble above it
+	counter := []byte{0xFF, 0xAF}
+	if carry, _ := bitwisebytes.Inc(counter, 12); !carry || !bytes.Equal(counter, []byte{0x00, 0xA0}) {
+		t.Errorf("unexpected wrap %X %v", counter, carry)
+	}
+	if borrow, _ := bitwisebytes.Dec(counter, 0); borrow || !bytes.Equal(counter, []byte{0xFF, 0x9F}) {
+		t.Errorf("unexpected decrement %X %v", counter, borrow)
+	}
+
+	if _, err := bitwisebytes.Add(make([]byte, 2), make([]byte, 2), make([]byte, 3), 0); !errors.Is(err, bitwisebytes.ErrBufferTooLong) {
+		t.Errorf("expected ErrBufferTooLong, got %v", err)
+	}
+	if _, err := bitwisebytes.Sub(make([]byte, 2), make([]byte, 1), make([]byte, 2), 0); !errors.Is(err, bitwisebytes.ErrBufferTooShort) {
+		t.Errorf("expected ErrBufferTooShort, got %v", err)
+	}
+	if _, err := bitwisebytes.Compare(make([]byte, 2), make([]byte, 2), 17); !errors.Is(err, bitwisebytes.ErrBufferTooShort) {
+		t.Errorf("expected ErrBufferTooShort, got %v", err)
+	}
+}
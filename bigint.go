@@ -0,0 +1,68 @@
+package bitwisebytes
+
+import "math/big"
+
+// ------------------------------------------------------------------
+//                   Conversions to/from math/big
+// ------------------------------------------------------------------
+// The buffers use the layouts of the rest of the package: LittleEndian
+// holds the least significant byte in b[0], as ByteSliceToWordSlice
+// expects, BigEndian holds it in b[len(b)-1].
+
+// ToBigInt returns the unsigned value of b laid out in the given order
+func ToBigInt(b []byte, order ByteOrder) *big.Int {
+	if order == BigEndian {
+		return new(big.Int).SetBytes(b)
+	}
+	return new(big.Int).SetBytes(reverseBytes(b))
+}
+
+// FromBigInt returns x as a (widthBits+7)/8 bytes slice laid out in the
+// given order. It fails with ErrValueOverflow when x is negative or needs
+// more than widthBits bits.
+func FromBigInt(x *big.Int, widthBits uint, order ByteOrder) ([]byte, error) {
+	if x.Sign() < 0 || uint(x.BitLen()) > widthBits {
+		return nil, fieldError("FromBigInt", 0, widthBits, ErrValueOverflow)
+	}
+	return fillBigInt(x, widthBits, order), nil
+}
+
+// ToSignedBigInt returns the two's complement value of b laid out in the
+// given order, the most significant bit of b is the sign bit
+func ToSignedBigInt(b []byte, order ByteOrder) *big.Int {
+	x := ToBigInt(b, order)
+	if len(b) > 0 && x.Bit(len(b)*8-1) == 1 {
+		x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(len(b))*8))
+	}
+	return x
+}
+
+// FromSignedBigInt returns the widthBits bits two's complement encoding of
+// x as a (widthBits+7)/8 bytes slice laid out in the given order. The bits
+// above widthBits hold copies of the sign bit so ToSignedBigInt reads x
+// back. It fails with ErrValueOverflow when x does not fit in widthBits.
+func FromSignedBigInt(x *big.Int, widthBits uint, order ByteOrder) ([]byte, error) {
+	if widthBits == 0 {
+		return nil, fieldError("FromSignedBigInt", 0, widthBits, ErrWidthOutOfRange)
+	}
+	// -2^(widthBits-1) <= x < 2^(widthBits-1)
+	limit := new(big.Int).Lsh(big.NewInt(1), widthBits-1)
+	if x.Cmp(limit) >= 0 || x.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fieldError("FromSignedBigInt", 0, widthBits, ErrValueOverflow)
+	}
+	if x.Sign() >= 0 {
+		return fillBigInt(x, widthBits, order), nil
+	}
+	size := (widthBits + 7) / 8
+	return fillBigInt(new(big.Int).Add(x, new(big.Int).Lsh(big.NewInt(1), size*8)), widthBits, order), nil
+}
+
+// fillBigInt returns the non negative x, which fits in the buffer, as a
+// (widthBits+7)/8 bytes slice laid out in the given order
+func fillBigInt(x *big.Int, widthBits uint, order ByteOrder) []byte {
+	b := x.FillBytes(make([]byte, (widthBits+7)/8))
+	if order != BigEndian {
+		reverseBytesInPlace(b)
+	}
+	return b
+}
@@ -0,0 +1,79 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestBigInt(t *testing.T) {
+	for _, order := range []bitwisebytes.ByteOrder{bitwisebytes.LittleEndian, bitwisebytes.BigEndian} {
+		for i := 0; i < testLooops; i++ {
+			widthBits := uint(rand.Intn(200) + 1)
+			x := new(big.Int).Rand(rand.New(rand.NewSource(rand.Int63())), new(big.Int).Lsh(big.NewInt(1), widthBits))
+
+			b, err := bitwisebytes.FromBigInt(x, widthBits, order)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(b) != int(widthBits+7)/8 {
+				t.Fatalf("width:%d unexpected length %d", widthBits, len(b))
+			}
+			if r := bitwisebytes.ToBigInt(b, order); r.Cmp(x) != 0 {
+				t.Fatalf("width:%d mistmatch: %v != %v", widthBits, r, x)
+			}
+
+			// Signed values take the top bit as the sign bit
+			s := new(big.Int).Sub(x, new(big.Int).Lsh(big.NewInt(1), widthBits-1))
+			b, err = bitwisebytes.FromSignedBigInt(s, widthBits, order)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if r := bitwisebytes.ToSignedBigInt(b, order); r.Cmp(s) != 0 {
+				t.Fatalf("width:%d signed mistmatch: %v != %v", widthBits, r, s)
+			}
+		}
+	}
+
+	// The little endian layout matches the words of ByteSliceToWordSlice
+	x, _ := new(big.Int).SetString("0102030405060708090A0B0C", 16)
+	b, _ := bitwisebytes.FromBigInt(x, 96, bitwisebytes.LittleEndian)
+	if !bytes.Equal(b, []byte{0x0C, 0x0B, 0x0A, 0x09, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}) {
+		t.Errorf("unexpected layout %X", b)
+	}
+	if b, _ := bitwisebytes.FromSignedBigInt(big.NewInt(-2), 12, bitwisebytes.BigEndian); !bytes.Equal(b, []byte{0xFF, 0xFE}) {
+		t.Errorf("unexpected encoding %X", b)
+	}
+
+	cases := []struct {
+		name     string
+		x        *big.Int
+		width    uint
+		signed   bool
+		expected error
+	}{
+		{"too wide", big.NewInt(256), 8, false, bitwisebytes.ErrValueOverflow},
+		{"negative", big.NewInt(-1), 8, false, bitwisebytes.ErrValueOverflow},
+		{"signed too big", big.NewInt(128), 8, true, bitwisebytes.ErrValueOverflow},
+		{"signed too small", big.NewInt(-129), 8, true, bitwisebytes.ErrValueOverflow},
+		{"signed zero width", big.NewInt(0), 0, true, bitwisebytes.ErrWidthOutOfRange},
+	}
+	for _, c := range cases {
+		var err error
+		if c.signed {
+			_, err = bitwisebytes.FromSignedBigInt(c.x, c.width, bitwisebytes.LittleEndian)
+		} else {
+			_, err = bitwisebytes.FromBigInt(c.x, c.width, bitwisebytes.LittleEndian)
+		}
+		if !errors.Is(err, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, err)
+		}
+	}
+	if _, err := bitwisebytes.FromSignedBigInt(big.NewInt(-128), 8, bitwisebytes.LittleEndian); err != nil {
+		t.Errorf("-128 fits in 8 bits, got %v", err)
+	}
+}
@@ -1,9 +1,6 @@
 package bitwisebytes
 
-import (
-	"encoding/binary"
-	"fmt"
-)
+import "encoding/binary"
 
 //ByteOrder specifies how to convert byte sequences into
 // 16-, 32-, or 64-bit unsigned integers.
@@ -32,6 +29,9 @@ var LittleEndian littleEndian
 // BigEndian is the big-endian implementation of ByteOrder.
 var BigEndian bigEndian
 
+var _ ByteOrder = LittleEndian
+var _ ByteOrder = BigEndian
+
 type littleEndian struct{}
 
 // -----------------------
@@ -43,6 +43,11 @@ func (littleEndian) Uint8(b []byte) uint8 {
 	return uint8(b[0])
 }
 
+func (littleEndian) PutUint8(b []byte, v uint8) {
+	_ = b[0] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+}
+
 func (littleEndian) OrPutUint8(b []byte, v uint8) {
 	_ = b[0] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -52,6 +57,12 @@ func (littleEndian) Uint16(b []byte) uint16 {
 	return binary.LittleEndian.Uint16(b)
 }
 
+func (littleEndian) PutUint16(b []byte, v uint16) {
+	_ = b[1] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+}
+
 func (littleEndian) OrPutUint16(b []byte, v uint16) {
 	_ = b[1] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -63,6 +74,13 @@ func (littleEndian) Uint24(b []byte) uint32 {
 	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
 }
 
+func (littleEndian) PutUint24(b []byte, v uint32) {
+	_ = b[2] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
 func (littleEndian) OrPutUint24(b []byte, v uint32) {
 	_ = b[2] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -75,6 +93,14 @@ func (littleEndian) Uint32(b []byte) uint32 {
 	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
 }
 
+func (littleEndian) PutUint32(b []byte, v uint32) {
+	_ = b[3] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+}
+
 func (littleEndian) OrPutUint32(b []byte, v uint32) {
 	_ = b[3] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -89,6 +115,15 @@ func (littleEndian) Uint40(b []byte) uint64 {
 		uint64(b[4])<<32
 }
 
+func (littleEndian) PutUint40(b []byte, v uint64) {
+	_ = b[4] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+}
+
 func (littleEndian) OrPutUint40(b []byte, v uint64) {
 	_ = b[4] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -104,6 +139,16 @@ func (littleEndian) Uint48(b []byte) uint64 {
 		uint64(b[4])<<32 | uint64(b[5])<<40
 }
 
+func (littleEndian) PutUint48(b []byte, v uint64) {
+	_ = b[5] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+	b[5] = byte(v >> 40)
+}
+
 func (littleEndian) OrPutUint48(b []byte, v uint64) {
 	_ = b[5] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -120,6 +165,17 @@ func (littleEndian) Uint56(b []byte) uint64 {
 		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48
 }
 
+func (littleEndian) PutUint56(b []byte, v uint64) {
+	_ = b[6] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+	b[5] = byte(v >> 40)
+	b[6] = byte(v >> 48)
+}
+
 func (littleEndian) OrPutUint56(b []byte, v uint64) {
 	_ = b[6] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -137,6 +193,18 @@ func (littleEndian) Uint64(b []byte) uint64 {
 		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
 }
 
+func (littleEndian) PutUint64(b []byte, v uint64) {
+	_ = b[7] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+	b[5] = byte(v >> 40)
+	b[6] = byte(v >> 48)
+	b[7] = byte(v >> 56)
+}
+
 func (littleEndian) OrPutUint64(b []byte, v uint64) {
 	_ = b[7] // early bounds check to guarantee safety of writes below
 	b[0] |= byte(v)
@@ -152,237 +220,112 @@ func (littleEndian) OrPutUint64(b []byte, v uint64) {
 // ------------------------------------------------------------------
 //                   Operations on/to shifted bytes
 // ------------------------------------------------------------------
+// PutUintNShiftedBytes ORs v into b, ClearPutUintNShiftedBytes first clears
+// the field bits so a populated buffer can be overwritten.
+// The UintNShiftedBytes getters AND the field with mask, on 32 bits
+// platforms pass -1 to keep every bit of fields wider than 31 bits.
 
-func (l littleEndian) Uint8ShiftedBytes(mask, offset int, b []byte) uint8 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-
-	return uint8(l.Uint16(b)>>uint(offset)) & uint8(mask)
+func (littleEndian) Uint8ShiftedBytes(mask, offset int, b []byte) uint8 {
+	return uint8(shiftedUint(LittleEndian, "LittleEndian.Uint8ShiftedBytes", offset, b, 8)) & uint8(mask)
 }
 
-func (l littleEndian) PutUint8ShiftedBytes(offset int, b []byte, v uint8) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 2:
-		l.OrPutUint16(b, uint16(v)<<uint(offset))
-	case 1:
-		l.OrPutUint8(b, uint8(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%d", len(b)))
-	}
-
+func (littleEndian) PutUint8ShiftedBytes(offset int, b []byte, v uint8) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint8ShiftedBytes", offset, b, 8, uint64(v), false)
 }
 
-func (l littleEndian) Uint16ShiftedBytes(mask, offset int, b []byte) uint16 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[2]
-	return uint16(l.Uint24(b)>>uint(offset)) & uint16(mask)
+func (littleEndian) ClearPutUint8ShiftedBytes(offset int, b []byte, v uint8) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint8ShiftedBytes", offset, b, 8, uint64(v), true)
 }
 
-func (l littleEndian) PutUint16ShiftedBytes(offset int, b []byte, v uint16) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 3:
-		_ = b[2]
-		l.OrPutUint24(b, uint32(v)<<uint(offset))
-	case 2:
-		_ = b[1]
-		l.OrPutUint16(b, uint16(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
-
+func (littleEndian) Uint16ShiftedBytes(mask, offset int, b []byte) uint16 {
+	return uint16(shiftedUint(LittleEndian, "LittleEndian.Uint16ShiftedBytes", offset, b, 16)) & uint16(mask)
 }
 
-func (l littleEndian) Uint24ShiftedBytes(mask, offset int, b []byte) uint32 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[3] // bounds check hint to compiler; see golang.org/issue/14808
-	return uint32(l.Uint32(b)>>uint(offset)) & uint32(mask)
+func (littleEndian) PutUint16ShiftedBytes(offset int, b []byte, v uint16) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint16ShiftedBytes", offset, b, 16, uint64(v), false)
 }
 
-func (l littleEndian) PutUint24ShiftedBytes(offset int, b []byte, v uint32) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 4:
-		_ = b[3]
-		l.OrPutUint32(b, uint32(v)<<uint(offset))
-	case 3:
-		_ = b[2]
-		l.OrPutUint24(b, uint32(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%d", len(b)))
-	}
-
+func (littleEndian) ClearPutUint16ShiftedBytes(offset int, b []byte, v uint16) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint16ShiftedBytes", offset, b, 16, uint64(v), true)
 }
 
-func (l littleEndian) Uint32ShiftedBytes(mask, offset int, b []byte) uint32 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[3] // bounds check hint to compiler; see golang.org/issue/14808
-	return uint32(l.Uint40(b)>>uint(offset)) & uint32(mask)
+func (littleEndian) Uint24ShiftedBytes(mask, offset int, b []byte) uint32 {
+	return uint32(shiftedUint(LittleEndian, "LittleEndian.Uint24ShiftedBytes", offset, b, 24)) & uint32(mask)
 }
 
-func (l littleEndian) PutUint32ShiftedBytes(offset int, b []byte, v uint32) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 5:
-		_ = b[4]
-		l.OrPutUint40(b, uint64(v)<<uint(offset))
-	case 4:
-		_ = b[3]
-		l.OrPutUint32(b, uint32(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
-
+func (littleEndian) PutUint24ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint24ShiftedBytes", offset, b, 24, uint64(v), false)
 }
 
-func (l littleEndian) Uint40ShiftedBytes(mask, offset int, b []byte) uint64 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[4] // bounds check hint to compiler; see golang.org/issue/14808
-
-	return uint64(l.Uint48(b)>>uint(offset)) & uint64(mask)
+func (littleEndian) ClearPutUint24ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint24ShiftedBytes", offset, b, 24, uint64(v), true)
 }
 
-func (l littleEndian) PutUint40ShiftedBytes(offset int, b []byte, v uint64) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 6:
-		_ = b[5]
-		l.OrPutUint48(b, uint64(v)<<uint(offset))
-	case 5:
-		_ = b[4]
-		l.OrPutUint40(b, uint64(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
-
+func (littleEndian) Uint32ShiftedBytes(mask, offset int, b []byte) uint32 {
+	return uint32(shiftedUint(LittleEndian, "LittleEndian.Uint32ShiftedBytes", offset, b, 32)) & uint32(mask)
 }
 
-func (l littleEndian) Uint48ShiftedBytes(mask, offset int, b []byte) uint64 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[5] // bounds check hint to compiler; see golang.org/issue/14808
+func (littleEndian) PutUint32ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint32ShiftedBytes", offset, b, 32, uint64(v), false)
+}
 
-	return uint64(l.Uint56(b)>>uint(offset)) & uint64(mask)
+func (littleEndian) ClearPutUint32ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint32ShiftedBytes", offset, b, 32, uint64(v), true)
 }
 
-func (l littleEndian) PutUint48ShiftedBytes(offset int, b []byte, v uint64) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 7:
-		_ = b[6]
-		l.OrPutUint56(b, uint64(v)<<uint(offset))
-	case 6:
-		_ = b[5]
-		l.OrPutUint48(b, uint64(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
+func (littleEndian) Uint40ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(LittleEndian, "LittleEndian.Uint40ShiftedBytes", offset, b, 40) & uint64(mask)
 }
 
-func (l littleEndian) Uint56ShiftedBytes(mask, offset int, b []byte) uint64 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[6] // bounds check hint to compiler; see golang.org/issue/14808
+func (littleEndian) PutUint40ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint40ShiftedBytes", offset, b, 40, v, false)
+}
 
-	return uint64(l.Uint64(b)>>uint(offset)) & uint64(mask)
+func (littleEndian) ClearPutUint40ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint40ShiftedBytes", offset, b, 40, v, true)
 }
 
-func (l littleEndian) PutUint56ShiftedBytes(offset int, b []byte, v uint64) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 8:
-		_ = b[7]
-		l.OrPutUint64(b, uint64(v)<<uint(offset))
-	case 7:
-		_ = b[6]
-		l.OrPutUint56(b, uint64(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
+func (littleEndian) Uint48ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(LittleEndian, "LittleEndian.Uint48ShiftedBytes", offset, b, 48) & uint64(mask)
+}
 
+func (littleEndian) PutUint48ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint48ShiftedBytes", offset, b, 48, v, false)
 }
 
-func (l littleEndian) Uint64ShiftedBytes(mask, offset int, b []byte) uint64 {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	_ = b[7] // bounds check hint to compiler; see golang.org/issue/14808
+func (littleEndian) ClearPutUint48ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint48ShiftedBytes", offset, b, 48, v, true)
+}
 
-	tmpB := make([]byte, len(b))
-	copy(tmpB, b)
-	tmpC, err := ShiftRight(tmpB, uint(offset))
+func (littleEndian) Uint56ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(LittleEndian, "LittleEndian.Uint56ShiftedBytes", offset, b, 56) & uint64(mask)
+}
 
-	if err != nil {
-		panic(err.Error())
-	}
+func (littleEndian) PutUint56ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint56ShiftedBytes", offset, b, 56, v, false)
+}
 
-	return uint64(l.Uint64(tmpC) & uint64(mask))
+func (littleEndian) ClearPutUint56ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint56ShiftedBytes", offset, b, 56, v, true)
 }
 
-func (l littleEndian) PutUint64ShiftedBytes(offset int, b []byte, v uint64) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
-	switch len(b) {
-	case 9:
-		{
-			_ = b[8]
-
-			tmpV := make([]byte, 9)
-			l.OrPutUint64(tmpV, uint64(v))
-			tmpV2, err := ShiftLeft(tmpV, uint(offset))
-
-			if err != nil {
-				panic(err.Error())
-			}
-
-			for i, aByte := range tmpV2 {
-				b[i] |= aByte
-			}
-		}
+func (littleEndian) Uint64ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(LittleEndian, "LittleEndian.Uint64ShiftedBytes", offset, b, 64) & uint64(mask)
+}
 
-	case 8:
-		_ = b[7]
-		l.OrPutUint64(b, uint64(v)<<uint(offset))
-	default:
-		panic(fmt.Sprintf("incorrect size:%s", len(b)))
-	}
+func (littleEndian) PutUint64ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.PutUint64ShiftedBytes", offset, b, 64, v, false)
+}
 
+func (littleEndian) ClearPutUint64ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(LittleEndian, "LittleEndian.ClearPutUint64ShiftedBytes", offset, b, 64, v, true)
 }
 
 func (l littleEndian) PutBytesSliceShiftedBytes(offset int, out, in []byte) {
-	if offset > 7 {
-		panic("offset > 7")
-	}
+	checkShiftedOffset("LittleEndian.PutBytesSliceShiftedBytes", offset, uint(len(in))*8)
 	tmpOut, err := ShiftLeft(in, uint(offset))
 	if err != nil {
-		panic(err.Error())
+		panic(err)
 	}
 
 	for i, _ := range tmpOut {
@@ -391,12 +334,10 @@ func (l littleEndian) PutBytesSliceShiftedBytes(offset int, out, in []byte) {
 }
 
 func (l littleEndian) BytesSliceShiftedBytes(mask []byte, offset int, b []byte) []byte {
-	if offset > 7 {
-		panic("offset > 7")
-	}
+	checkShiftedOffset("LittleEndian.BytesSliceShiftedBytes", offset, uint(len(mask))*8)
 	returnBytes, err := ShiftRight(b, uint(offset))
 	if err != nil {
-		panic(err.Error())
+		panic(err)
 	}
 
 	for i, aByteMask := range mask {
@@ -405,11 +346,26 @@ func (l littleEndian) BytesSliceShiftedBytes(mask []byte, offset int, b []byte)
 	return returnBytes[0:len(mask)]
 }
 
-// --------------------------------
-// BigEndian TODO: incomplete code
-// --------------------------------
+// -----------------------
+// Big Endian
+// -----------------------
 type bigEndian struct{}
 
+func (bigEndian) Uint8(b []byte) uint8 {
+	_ = b[0] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint8(b[0])
+}
+
+func (bigEndian) PutUint8(b []byte, v uint8) {
+	_ = b[0] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+}
+
+func (bigEndian) OrPutUint8(b []byte, v uint8) {
+	_ = b[0] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v)
+}
+
 func (bigEndian) Uint16(b []byte) uint16 {
 	_ = b[1] // bounds check hint to compiler; see golang.org/issue/14808
 	return uint16(b[1]) | uint16(b[0])<<8
@@ -421,6 +377,31 @@ func (bigEndian) PutUint16(b []byte, v uint16) {
 	b[1] = byte(v)
 }
 
+func (bigEndian) OrPutUint16(b []byte, v uint16) {
+	_ = b[1] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 8)
+	b[1] |= byte(v)
+}
+
+func (bigEndian) Uint24(b []byte) uint32 {
+	_ = b[2] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint32(b[2]) | uint32(b[1])<<8 | uint32(b[0])<<16
+}
+
+func (bigEndian) PutUint24(b []byte, v uint32) {
+	_ = b[2] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v >> 16)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v)
+}
+
+func (bigEndian) OrPutUint24(b []byte, v uint32) {
+	_ = b[2] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 16)
+	b[1] |= byte(v >> 8)
+	b[2] |= byte(v)
+}
+
 func (bigEndian) Uint32(b []byte) uint32 {
 	_ = b[3] // bounds check hint to compiler; see golang.org/issue/14808
 	return uint32(b[3]) | uint32(b[2])<<8 | uint32(b[1])<<16 | uint32(b[0])<<24
@@ -434,6 +415,92 @@ func (bigEndian) PutUint32(b []byte, v uint32) {
 	b[3] = byte(v)
 }
 
+func (bigEndian) OrPutUint32(b []byte, v uint32) {
+	_ = b[3] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 24)
+	b[1] |= byte(v >> 16)
+	b[2] |= byte(v >> 8)
+	b[3] |= byte(v)
+}
+
+func (bigEndian) Uint40(b []byte) uint64 {
+	_ = b[4] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint64(b[4]) | uint64(b[3])<<8 | uint64(b[2])<<16 | uint64(b[1])<<24 |
+		uint64(b[0])<<32
+}
+
+func (bigEndian) PutUint40(b []byte, v uint64) {
+	_ = b[4] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v >> 32)
+	b[1] = byte(v >> 24)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 8)
+	b[4] = byte(v)
+}
+
+func (bigEndian) OrPutUint40(b []byte, v uint64) {
+	_ = b[4] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 32)
+	b[1] |= byte(v >> 24)
+	b[2] |= byte(v >> 16)
+	b[3] |= byte(v >> 8)
+	b[4] |= byte(v)
+}
+
+func (bigEndian) Uint48(b []byte) uint64 {
+	_ = b[5] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint64(b[5]) | uint64(b[4])<<8 | uint64(b[3])<<16 | uint64(b[2])<<24 |
+		uint64(b[1])<<32 | uint64(b[0])<<40
+}
+
+func (bigEndian) PutUint48(b []byte, v uint64) {
+	_ = b[5] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	b[2] = byte(v >> 24)
+	b[3] = byte(v >> 16)
+	b[4] = byte(v >> 8)
+	b[5] = byte(v)
+}
+
+func (bigEndian) OrPutUint48(b []byte, v uint64) {
+	_ = b[5] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 40)
+	b[1] |= byte(v >> 32)
+	b[2] |= byte(v >> 24)
+	b[3] |= byte(v >> 16)
+	b[4] |= byte(v >> 8)
+	b[5] |= byte(v)
+}
+
+func (bigEndian) Uint56(b []byte) uint64 {
+	_ = b[6] // bounds check hint to compiler; see golang.org/issue/14808
+	return uint64(b[6]) | uint64(b[5])<<8 | uint64(b[4])<<16 | uint64(b[3])<<24 |
+		uint64(b[2])<<32 | uint64(b[1])<<40 | uint64(b[0])<<48
+}
+
+func (bigEndian) PutUint56(b []byte, v uint64) {
+	_ = b[6] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v >> 48)
+	b[1] = byte(v >> 40)
+	b[2] = byte(v >> 32)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 16)
+	b[5] = byte(v >> 8)
+	b[6] = byte(v)
+}
+
+func (bigEndian) OrPutUint56(b []byte, v uint64) {
+	_ = b[6] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 48)
+	b[1] |= byte(v >> 40)
+	b[2] |= byte(v >> 32)
+	b[3] |= byte(v >> 24)
+	b[4] |= byte(v >> 16)
+	b[5] |= byte(v >> 8)
+	b[6] |= byte(v)
+}
+
 func (bigEndian) Uint64(b []byte) uint64 {
 	_ = b[7] // bounds check hint to compiler; see golang.org/issue/14808
 	return uint64(b[7]) | uint64(b[6])<<8 | uint64(b[5])<<16 | uint64(b[4])<<24 |
@@ -451,3 +518,268 @@ func (bigEndian) PutUint64(b []byte, v uint64) {
 	b[6] = byte(v >> 8)
 	b[7] = byte(v)
 }
+
+func (bigEndian) OrPutUint64(b []byte, v uint64) {
+	_ = b[7] // early bounds check to guarantee safety of writes below
+	b[0] |= byte(v >> 56)
+	b[1] |= byte(v >> 48)
+	b[2] |= byte(v >> 40)
+	b[3] |= byte(v >> 32)
+	b[4] |= byte(v >> 24)
+	b[5] |= byte(v >> 16)
+	b[6] |= byte(v >> 8)
+	b[7] |= byte(v)
+}
+
+// ------------------------------------------------------------------
+//                   Operations on/to shifted bytes
+// ------------------------------------------------------------------
+// In big endian the least significant byte is the last one, so the
+// offset counts from the LSB of the last byte and the extra byte that
+// holds the spilled bits is the first one.
+
+func (bigEndian) Uint8ShiftedBytes(mask, offset int, b []byte) uint8 {
+	return uint8(shiftedUint(BigEndian, "BigEndian.Uint8ShiftedBytes", offset, b, 8)) & uint8(mask)
+}
+
+func (bigEndian) PutUint8ShiftedBytes(offset int, b []byte, v uint8) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint8ShiftedBytes", offset, b, 8, uint64(v), false)
+}
+
+func (bigEndian) ClearPutUint8ShiftedBytes(offset int, b []byte, v uint8) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint8ShiftedBytes", offset, b, 8, uint64(v), true)
+}
+
+func (bigEndian) Uint16ShiftedBytes(mask, offset int, b []byte) uint16 {
+	return uint16(shiftedUint(BigEndian, "BigEndian.Uint16ShiftedBytes", offset, b, 16)) & uint16(mask)
+}
+
+func (bigEndian) PutUint16ShiftedBytes(offset int, b []byte, v uint16) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint16ShiftedBytes", offset, b, 16, uint64(v), false)
+}
+
+func (bigEndian) ClearPutUint16ShiftedBytes(offset int, b []byte, v uint16) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint16ShiftedBytes", offset, b, 16, uint64(v), true)
+}
+
+func (bigEndian) Uint24ShiftedBytes(mask, offset int, b []byte) uint32 {
+	return uint32(shiftedUint(BigEndian, "BigEndian.Uint24ShiftedBytes", offset, b, 24)) & uint32(mask)
+}
+
+func (bigEndian) PutUint24ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint24ShiftedBytes", offset, b, 24, uint64(v), false)
+}
+
+func (bigEndian) ClearPutUint24ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint24ShiftedBytes", offset, b, 24, uint64(v), true)
+}
+
+func (bigEndian) Uint32ShiftedBytes(mask, offset int, b []byte) uint32 {
+	return uint32(shiftedUint(BigEndian, "BigEndian.Uint32ShiftedBytes", offset, b, 32)) & uint32(mask)
+}
+
+func (bigEndian) PutUint32ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint32ShiftedBytes", offset, b, 32, uint64(v), false)
+}
+
+func (bigEndian) ClearPutUint32ShiftedBytes(offset int, b []byte, v uint32) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint32ShiftedBytes", offset, b, 32, uint64(v), true)
+}
+
+func (bigEndian) Uint40ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(BigEndian, "BigEndian.Uint40ShiftedBytes", offset, b, 40) & uint64(mask)
+}
+
+func (bigEndian) PutUint40ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint40ShiftedBytes", offset, b, 40, v, false)
+}
+
+func (bigEndian) ClearPutUint40ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint40ShiftedBytes", offset, b, 40, v, true)
+}
+
+func (bigEndian) Uint48ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(BigEndian, "BigEndian.Uint48ShiftedBytes", offset, b, 48) & uint64(mask)
+}
+
+func (bigEndian) PutUint48ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint48ShiftedBytes", offset, b, 48, v, false)
+}
+
+func (bigEndian) ClearPutUint48ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint48ShiftedBytes", offset, b, 48, v, true)
+}
+
+func (bigEndian) Uint56ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(BigEndian, "BigEndian.Uint56ShiftedBytes", offset, b, 56) & uint64(mask)
+}
+
+func (bigEndian) PutUint56ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint56ShiftedBytes", offset, b, 56, v, false)
+}
+
+func (bigEndian) ClearPutUint56ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint56ShiftedBytes", offset, b, 56, v, true)
+}
+
+func (bigEndian) Uint64ShiftedBytes(mask, offset int, b []byte) uint64 {
+	return shiftedUint(BigEndian, "BigEndian.Uint64ShiftedBytes", offset, b, 64) & uint64(mask)
+}
+
+func (bigEndian) PutUint64ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.PutUint64ShiftedBytes", offset, b, 64, v, false)
+}
+
+func (bigEndian) ClearPutUint64ShiftedBytes(offset int, b []byte, v uint64) {
+	putShiftedUint(BigEndian, "BigEndian.ClearPutUint64ShiftedBytes", offset, b, 64, v, true)
+}
+
+func (l bigEndian) PutBytesSliceShiftedBytes(offset int, out, in []byte) {
+	checkShiftedOffset("BigEndian.PutBytesSliceShiftedBytes", offset, uint(len(in))*8)
+	tmpOut, err := ShiftLeft(reverseBytes(in), uint(offset))
+	if err != nil {
+		panic(err)
+	}
+
+	// tmpOut is little endian, align its LSB with the last byte of out
+	for i := range tmpOut {
+		out[len(out)-1-i] |= tmpOut[i]
+	}
+}
+
+func (l bigEndian) BytesSliceShiftedBytes(mask []byte, offset int, b []byte) []byte {
+	checkShiftedOffset("BigEndian.BytesSliceShiftedBytes", offset, uint(len(mask))*8)
+	tmpBytes, err := ShiftRight(reverseBytes(b), uint(offset))
+	if err != nil {
+		panic(err)
+	}
+
+	// tmpBytes is little endian, take its len(mask) least significant bytes
+	returnBytes := make([]byte, len(mask))
+	for i, aByteMask := range mask {
+		returnBytes[i] = tmpBytes[len(mask)-1-i] & aByteMask
+	}
+	return returnBytes
+}
+
+// reverseBytes returns a copy of b with its bytes in reverse order
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	copy(r, b)
+	reverseBytesInPlace(r)
+	return r
+}
+
+// ------------------------------------------------------------------
+//                   Operations on/to arbitrary bit fields
+// ------------------------------------------------------------------
+// bitOffset is the absolute position of the field LSB, counted from the
+// least significant bit of the buffer. For little endian that is bit 0
+// of b[0], for big endian bit 0 of b[len(b)-1].
+
+// MaxFieldWidth is the widest field GetBits and SetBits can address
+const MaxFieldWidth = 64
+
+func checkField(op string, size int, bitOffset, width uint) error {
+	if width == 0 || width > MaxFieldWidth {
+		return fieldError(op, bitOffset, width, ErrWidthOutOfRange)
+	}
+	// Written so that a huge bitOffset can not wrap around
+	if bitOffset > uint(size)*8 || width > uint(size)*8-bitOffset {
+		return fieldError(op, bitOffset, width, ErrBufferTooShort)
+	}
+	return nil
+}
+
+// fieldByte returns the index of the byte that holds bit bitNum of b
+func fieldByte(size int, bitNum uint, bigEndian bool) uint {
+	if bigEndian {
+		return uint(size-1) - bitNum/8
+	}
+	return bitNum / 8
+}
+
+// loadField returns the width bits field found at bitOffset of b, loading
+// whole words like readChunk does. The field must fit in b.
+func loadField(b []byte, bitOffset, width uint, bigEndian bool) uint64 {
+	start, shift := int(bitOffset/8), bitOffset%8
+	var word, top uint64
+	if bigEndian {
+		word = loadReversed64(b, start)
+		if shift+width > 64 {
+			top = uint64(b[len(b)-9-start])
+		}
+	} else {
+		word = loadBytes64(b, start)
+		if shift+width > 64 {
+			top = uint64(b[start+8])
+		}
+	}
+	word = word>>shift | top<<(64-shift)
+	if width < 64 {
+		word &= 1<<width - 1
+	}
+	return word
+}
+
+// storeField overwrites the width bits field found at bitOffset of b with v,
+// storing whole words like writeChunk does. The field must fit in b and v
+// in width bits.
+func storeField(b []byte, bitOffset, width uint, v uint64, bigEndian bool) {
+	shift := bitOffset % 8
+	if shift+width > 64 {
+		// The top bits of the field spill into a ninth byte
+		low := 64 - shift
+		storeField(b, bitOffset+low, width-low, v>>low, bigEndian)
+		width, v = low, v&(1<<low-1)
+	}
+	if !bigEndian {
+		writeChunk(b, bitOffset, width, v)
+		return
+	}
+	start := int(bitOffset / 8)
+	if shift == 0 && width == 64 {
+		storeReversed64(b, start, v)
+		return
+	}
+	mask := (uint64(1)<<width - 1) << shift
+	storeReversed64(b, start, loadReversed64(b, start)&^mask|v<<shift&mask)
+}
+
+func getBits(b []byte, bitOffset, width uint, bigEndian bool) (uint64, error) {
+	if err := checkField("GetBits", len(b), bitOffset, width); err != nil {
+		return 0, err
+	}
+	return loadField(b, bitOffset, width, bigEndian), nil
+}
+
+func setBits(b []byte, bitOffset, width uint, v uint64, bigEndian bool) error {
+	if err := checkField("SetBits", len(b), bitOffset, width); err != nil {
+		return err
+	}
+	if width < 64 && v>>width != 0 {
+		return fieldError("SetBits", bitOffset, width, ErrValueOverflow)
+	}
+	storeField(b, bitOffset, width, v, bigEndian)
+	return nil
+}
+
+// GetBits returns the width bits field found at bitOffset of b
+func (littleEndian) GetBits(b []byte, bitOffset, width uint) (uint64, error) {
+	return getBits(b, bitOffset, width, false)
+}
+
+// SetBits overwrites the width bits field found at bitOffset of b with v
+func (littleEndian) SetBits(b []byte, bitOffset, width uint, v uint64) error {
+	return setBits(b, bitOffset, width, v, false)
+}
+
+// GetBits returns the width bits field found at bitOffset of b
+func (bigEndian) GetBits(b []byte, bitOffset, width uint) (uint64, error) {
+	return getBits(b, bitOffset, width, true)
+}
+
+// SetBits overwrites the width bits field found at bitOffset of b with v
+func (bigEndian) SetBits(b []byte, bitOffset, width uint, v uint64) error {
+	return setBits(b, bitOffset, width, v, true)
+}
@@ -5,15 +5,24 @@ import (
 	"math/rand"
 	"github.com/lagarciag/bitwisebytes"
 	"encoding/binary"
+	"errors"
 )
 
 const testLooops = 100
+
+// fieldOrder is a ByteOrder that also reads and writes bit fields, as
+// LittleEndian and BigEndian do
+type fieldOrder interface {
+	bitwisebytes.ByteOrder
+	GetBits(b []byte, bitOffset, width uint) (uint64, error)
+	SetBits(b []byte, bitOffset, width uint, v uint64) error
+}
 const debug = false
 
 func TestUint16(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,2)
-		randUint := uint16(rand.Intn(0xFFFFFFFF))
+		randUint := uint16(rand.Uint32())
 		bitwisebytes.LittleEndian.OrPutUint16(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint16(bytesSlice)
 		if resultUint != randUint {
@@ -42,7 +51,7 @@ func TestUint24(t *testing.T) {
 func TestUint32(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,4)
-		randUint := uint32(rand.Intn(0xFFFFFFFF))
+		randUint := rand.Uint32()
 		bitwisebytes.LittleEndian.OrPutUint32(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint32(bytesSlice)
 		if resultUint != randUint {
@@ -59,7 +68,7 @@ func TestUint40(t *testing.T) {
 	const max = 0xFFFFFFFFFF
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,size)
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		bitwisebytes.LittleEndian.OrPutUint40(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint40(bytesSlice)
 		if resultUint != randUint {
@@ -76,7 +85,7 @@ func TestUint48(t *testing.T) {
 	const max = 0xFFFFFFFFFFFF
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,size)
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		bitwisebytes.LittleEndian.OrPutUint48(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint48(bytesSlice)
 		if resultUint != randUint {
@@ -93,7 +102,7 @@ func TestUint56(t *testing.T) {
 	const max = 0xFFFFFFFFFFFFFF
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,size)
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		bitwisebytes.LittleEndian.OrPutUint56(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint56(bytesSlice)
 		if resultUint != randUint {
@@ -110,7 +119,7 @@ func TestUint64(t *testing.T) {
 	const max = 0xFFFFFFFFFFFFFFF
 	for i:=0;i<testLooops;i++ {
 		bytesSlice := make([]byte,size)
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		bitwisebytes.LittleEndian.OrPutUint64(bytesSlice,randUint)
 		resultUint := bitwisebytes.LittleEndian.Uint64(bytesSlice)
 		if resultUint != randUint {
@@ -340,13 +349,13 @@ func TestUint32Shifted(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		destSlice := make([]byte, intBytesSize + 1)
 
-		randUint := uint32(rand.Intn(max))
+		randUint := uint32(rand.Int63n(max))
 		randOffset := rand.Intn(8)
 		shiftedRandUint := uint64(randUint) << uint(randOffset)
 
 		// test PutUintShifted
 		bitwisebytes.LittleEndian.PutUint32ShiftedBytes(randOffset,destSlice,randUint)
-		resultUint := bitwisebytes.LittleEndian.Uint32ShiftedBytes(max,randOffset,destSlice)
+		resultUint := bitwisebytes.LittleEndian.Uint32ShiftedBytes(-1,randOffset,destSlice)
 
 		if resultUint != randUint {
 			t.Logf("randUint: 0x%x",randUint)
@@ -393,7 +402,7 @@ func TestUint32Shifted(t *testing.T) {
 		if shiftedInt != shiftedRandUint {
 			t.Errorf("0x%x --> 0x%x", shiftedInt, shiftedRandUint)
 		}
-		newInt = bitwisebytes.LittleEndian.Uint32ShiftedBytes(max,randOffset,reShiftedSlice)
+		newInt = bitwisebytes.LittleEndian.Uint32ShiftedBytes(-1,randOffset,reShiftedSlice)
 		if newInt != randUint {
 			t.Error(newInt,randUint)
 		}
@@ -408,13 +417,13 @@ func TestUint40Shifted(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		destSlice := make([]byte, intBytesSize + 1)
 
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		randOffset := rand.Intn(8)
 		shiftedRandUint := uint64(randUint) << uint(randOffset)
 
 		// test PutUintShifted
 		bitwisebytes.LittleEndian.PutUint40ShiftedBytes(randOffset,destSlice,randUint)
-		resultUint := bitwisebytes.LittleEndian.Uint40ShiftedBytes(max,randOffset,destSlice)
+		resultUint := bitwisebytes.LittleEndian.Uint40ShiftedBytes(-1,randOffset,destSlice)
 
 		if resultUint != randUint {
 			t.Logf("randUint: 0x%x",randUint)
@@ -461,7 +470,7 @@ func TestUint40Shifted(t *testing.T) {
 		if shiftedInt != shiftedRandUint {
 			t.Errorf("0x%x --> 0x%x", shiftedInt, shiftedRandUint)
 		}
-		newInt = bitwisebytes.LittleEndian.Uint40ShiftedBytes(max,randOffset,reShiftedSlice)
+		newInt = bitwisebytes.LittleEndian.Uint40ShiftedBytes(-1,randOffset,reShiftedSlice)
 		if newInt != randUint {
 			t.Error(newInt,randUint)
 		}
@@ -476,13 +485,13 @@ func TestUint48Shifted(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		destSlice := make([]byte, intBytesSize + 1)
 
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		randOffset := rand.Intn(8)
 		shiftedRandUint := uint64(randUint) << uint(randOffset)
 
 		// test PutUintShifted
 		bitwisebytes.LittleEndian.PutUint48ShiftedBytes(randOffset,destSlice,randUint)
-		resultUint := bitwisebytes.LittleEndian.Uint48ShiftedBytes(max,randOffset,destSlice)
+		resultUint := bitwisebytes.LittleEndian.Uint48ShiftedBytes(-1,randOffset,destSlice)
 
 		if resultUint != randUint {
 			t.Logf("randUint: 0x%x",randUint)
@@ -529,7 +538,7 @@ func TestUint48Shifted(t *testing.T) {
 		if shiftedInt != shiftedRandUint {
 			t.Errorf("0x%x --> 0x%x", shiftedInt, shiftedRandUint)
 		}
-		newInt = bitwisebytes.LittleEndian.Uint48ShiftedBytes(max,randOffset,reShiftedSlice)
+		newInt = bitwisebytes.LittleEndian.Uint48ShiftedBytes(-1,randOffset,reShiftedSlice)
 		if newInt != randUint {
 			t.Error(newInt,randUint)
 		}
@@ -544,13 +553,13 @@ func TestUint56Shifted(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		destSlice := make([]byte, intBytesSize + 1)
 
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		randOffset := rand.Intn(8)
 		shiftedRandUint := uint64(randUint) << uint(randOffset)
 
 		// test PutUintShifted
 		bitwisebytes.LittleEndian.PutUint56ShiftedBytes(randOffset,destSlice,randUint)
-		resultUint := bitwisebytes.LittleEndian.Uint56ShiftedBytes(max,randOffset,destSlice)
+		resultUint := bitwisebytes.LittleEndian.Uint56ShiftedBytes(-1,randOffset,destSlice)
 
 		if resultUint != randUint {
 			t.Logf("randUint: 0x%x",randUint)
@@ -597,7 +606,7 @@ func TestUint56Shifted(t *testing.T) {
 		if shiftedInt != shiftedRandUint {
 			t.Errorf("0x%x --> 0x%x", shiftedInt, shiftedRandUint)
 		}
-		newInt = bitwisebytes.LittleEndian.Uint56ShiftedBytes(max,randOffset,reShiftedSlice)
+		newInt = bitwisebytes.LittleEndian.Uint56ShiftedBytes(-1,randOffset,reShiftedSlice)
 		if newInt != randUint {
 			t.Error(newInt,randUint)
 		}
@@ -612,14 +621,14 @@ func TestUint64Shifted(t *testing.T) {
 	for i:=0;i<testLooops;i++ {
 		destSlice := make([]byte, intBytesSize + 1)
 
-		randUint := uint64(rand.Intn(max))
+		randUint := uint64(rand.Int63n(max))
 		randOffset := rand.Intn(8)
 		//shiftedRandUint := uint64(randUint) << uint(randOffset)
 
 		// test PutUintShifted
 		bitwisebytes.LittleEndian.PutUint64ShiftedBytes(randOffset,destSlice,randUint)
-		resultUint := bitwisebytes.LittleEndian.Uint64ShiftedBytes(max,randOffset,destSlice)
-		//resultTmp := bitwisebytes.LittleEndian.Uint64ShiftedBytes(max,0,destSlice)
+		resultUint := bitwisebytes.LittleEndian.Uint64ShiftedBytes(-1,randOffset,destSlice)
+		//resultTmp := bitwisebytes.LittleEndian.Uint64ShiftedBytes(-1,0,destSlice)
 
 		if resultUint != randUint {
 			t.Logf("randUint: 0x%x",randUint)
@@ -671,7 +680,7 @@ func TestUint64Shifted(t *testing.T) {
 		if shiftedInt != shiftedRandUint {
 			t.Errorf("0x%x --> 0x%x", shiftedInt, shiftedRandUint)
 		}
-		newInt = bitwisebytes.LittleEndian.Uint56ShiftedBytes(max,randOffset,reShiftedSlice)
+		newInt = bitwisebytes.LittleEndian.Uint56ShiftedBytes(-1,randOffset,reShiftedSlice)
 		if newInt != randUint {
 			t.Error(newInt,randUint)
 		}
@@ -767,3 +776,311 @@ func TestBytesShifted(t *testing.T) {
 	}
 }
 
+
+func TestBigEndianUint(t *testing.T) {
+	for i:=0;i<testLooops;i++ {
+		bytesSlice := make([]byte,8)
+		randUint := uint64(rand.Int63())
+
+		bitwisebytes.BigEndian.OrPutUint64(bytesSlice,randUint)
+		if binary.BigEndian.Uint64(bytesSlice) != randUint {
+			t.Errorf("mistmatch: 0x%X != 0x%X", randUint, binary.BigEndian.Uint64(bytesSlice))
+		}
+
+		if r := bitwisebytes.BigEndian.Uint8(bytesSlice[7:]); r != uint8(randUint) {
+			t.Errorf("Uint8 mistmatch: 0x%X != 0x%X", uint8(randUint), r)
+		}
+		if r := bitwisebytes.BigEndian.Uint16(bytesSlice[6:]); r != uint16(randUint) {
+			t.Errorf("Uint16 mistmatch: 0x%X != 0x%X", uint16(randUint), r)
+		}
+		if r := bitwisebytes.BigEndian.Uint24(bytesSlice[5:]); r != uint32(randUint)&0xFFFFFF {
+			t.Errorf("Uint24 mistmatch: 0x%X != 0x%X", uint32(randUint)&0xFFFFFF, r)
+		}
+		if r := bitwisebytes.BigEndian.Uint32(bytesSlice[4:]); r != uint32(randUint) {
+			t.Errorf("Uint32 mistmatch: 0x%X != 0x%X", uint32(randUint), r)
+		}
+		if r := bitwisebytes.BigEndian.Uint40(bytesSlice[3:]); r != randUint&0xFFFFFFFFFF {
+			t.Errorf("Uint40 mistmatch: 0x%X != 0x%X", randUint&0xFFFFFFFFFF, r)
+		}
+		if r := bitwisebytes.BigEndian.Uint48(bytesSlice[2:]); r != randUint&0xFFFFFFFFFFFF {
+			t.Errorf("Uint48 mistmatch: 0x%X != 0x%X", randUint&0xFFFFFFFFFFFF, r)
+		}
+		if r := bitwisebytes.BigEndian.Uint56(bytesSlice[1:]); r != randUint&0xFFFFFFFFFFFFFF {
+			t.Errorf("Uint56 mistmatch: 0x%X != 0x%X", randUint&0xFFFFFFFFFFFFFF, r)
+		}
+
+		for size := 3; size <= 7; size++ {
+			smallSlice := make([]byte,size)
+			switch size {
+			case 3:
+				bitwisebytes.BigEndian.OrPutUint24(smallSlice,uint32(randUint))
+			case 4:
+				bitwisebytes.BigEndian.OrPutUint32(smallSlice,uint32(randUint))
+			case 5:
+				bitwisebytes.BigEndian.OrPutUint40(smallSlice,randUint)
+			case 6:
+				bitwisebytes.BigEndian.OrPutUint48(smallSlice,randUint)
+			case 7:
+				bitwisebytes.BigEndian.OrPutUint56(smallSlice,randUint)
+			}
+			for j , aByte := range smallSlice {
+				if aByte != bytesSlice[8-size+j] {
+					t.Errorf("size %d byte %d: 0x%X != 0x%X", size, j, aByte, bytesSlice[8-size+j])
+				}
+			}
+		}
+	}
+}
+
+func TestBigEndianShifted(t *testing.T) {
+	for i:=0;i<testLooops;i++ {
+		randOffset := rand.Intn(8)
+
+		destSlice := make([]byte, 3)
+		randUint16 := uint16(rand.Intn(0xFFFF))
+		bitwisebytes.BigEndian.PutUint16ShiftedBytes(randOffset,destSlice,randUint16)
+		if r := bitwisebytes.BigEndian.Uint16ShiftedBytes(0xFFFF,randOffset,destSlice); r != randUint16 {
+			t.Errorf("mistmatch: 0x%X != 0x%X offset:%d %v", randUint16, r, randOffset, destSlice)
+		}
+		if r := bitwisebytes.BigEndian.Uint24(destSlice); r != uint32(randUint16)<<uint(randOffset) {
+			t.Errorf("mistmatch: 0x%X != 0x%X offset:%d", uint32(randUint16)<<uint(randOffset), r, randOffset)
+		}
+
+		destSlice = make([]byte, 5)
+		randUint32 := rand.Uint32()
+		bitwisebytes.BigEndian.PutUint32ShiftedBytes(randOffset,destSlice,randUint32)
+		if r := bitwisebytes.BigEndian.Uint32ShiftedBytes(-1,randOffset,destSlice); r != randUint32 {
+			t.Errorf("mistmatch: 0x%X != 0x%X offset:%d %v", randUint32, r, randOffset, destSlice)
+		}
+
+		destSlice = make([]byte, 8)
+		randUint56 := rand.Uint64() & 0xFFFFFFFFFFFFFF
+		bitwisebytes.BigEndian.PutUint56ShiftedBytes(randOffset,destSlice,randUint56)
+		if r := bitwisebytes.BigEndian.Uint56ShiftedBytes(-1,randOffset,destSlice); r != randUint56 {
+			t.Errorf("mistmatch: 0x%X != 0x%X offset:%d %v", randUint56, r, randOffset, destSlice)
+		}
+
+		destSlice = make([]byte, 9)
+		randUint64 := rand.Uint64()
+		bitwisebytes.BigEndian.PutUint64ShiftedBytes(randOffset,destSlice,randUint64)
+		if r := bitwisebytes.BigEndian.Uint64ShiftedBytes(-1,randOffset,destSlice); r != randUint64 {
+			t.Errorf("mistmatch: 0x%X != 0x%X offset:%d %v", randUint64, r, randOffset, destSlice)
+		}
+
+		// Compare against the little endian layout of the same value
+		leSlice := make([]byte, 9)
+		bitwisebytes.LittleEndian.PutUint64ShiftedBytes(randOffset,leSlice,randUint64)
+		for j , aByte := range destSlice {
+			if aByte != leSlice[8-j] {
+				t.Errorf("byte %d: 0x%X != 0x%X", j, aByte, leSlice[8-j])
+			}
+		}
+	}
+}
+
+func TestBigEndianBytesShifted(t *testing.T) {
+	intBytesSize := rand.Intn(100) + 8
+
+	randSlice := make([]byte,intBytesSize)
+	maskSlice := make([]byte,intBytesSize)
+
+	for i:=0;i<testLooops;i++ {
+		destSlice := make([]byte, intBytesSize + 1)
+
+		for i := range randSlice {
+			randSlice[i] = byte(rand.Intn(255))
+			maskSlice[i] = byte(0xFF)
+		}
+
+		randOffset := rand.Intn(8)
+
+		bitwisebytes.BigEndian.PutBytesSliceShiftedBytes(randOffset,destSlice,randSlice)
+		resultSlice := bitwisebytes.BigEndian.BytesSliceShiftedBytes(maskSlice,randOffset,destSlice)
+
+		// The most significant byte loses the bits shifted out of in
+		for i , aByte := range resultSlice {
+			if aByte != randSlice[i] && i != 0 {
+				t.Log(resultSlice)
+				t.Log(randSlice)
+				t.Errorf("mistmatch at %d: 0x%X != 0x%X", i, randSlice[i], aByte)
+			}
+		}
+	}
+}
+
+// refBit returns bit bitNum of b counting from the buffer LSB
+func refBit(b []byte, bitNum uint, bigEndian bool) uint64 {
+	i := bitNum / 8
+	if bigEndian {
+		i = uint(len(b)) - 1 - i
+	}
+	return uint64(b[i]>>(bitNum%8)) & 1
+}
+
+func TestGetSetBits(t *testing.T) {
+	orders := map[string]fieldOrder{
+		"LittleEndian": bitwisebytes.LittleEndian,
+		"BigEndian":    bitwisebytes.BigEndian,
+	}
+	for name, order := range orders {
+		bigEndian := name == "BigEndian"
+		for width := uint(1); width <= 64; width++ {
+			for i := 0; i < testLooops; i++ {
+				size := rand.Intn(16) + 8
+				bytesSlice := make([]byte, size)
+				rand.Read(bytesSlice)
+				original := append([]byte(nil), bytesSlice...)
+				offset := uint(rand.Intn(size*8 - int(width) + 1))
+
+				v, err := order.GetBits(bytesSlice, offset, width)
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+				for bit := uint(0); bit < width; bit++ {
+					if (v>>bit)&1 != refBit(bytesSlice, offset+bit, bigEndian) {
+						t.Fatalf("%s GetBits offset:%d width:%d bit %d mistmatch", name, offset, width, bit)
+					}
+				}
+
+				newV := rand.Uint64()
+				if width < 64 {
+					newV &= 1<<width - 1
+				}
+				if err := order.SetBits(bytesSlice, offset, width, newV); err != nil {
+					t.Fatal(err.Error())
+				}
+				for bit := uint(0); bit < uint(size)*8; bit++ {
+					expected := refBit(original, bit, bigEndian)
+					if bit >= offset && bit < offset+width {
+						expected = (newV >> (bit - offset)) & 1
+					}
+					if refBit(bytesSlice, bit, bigEndian) != expected {
+						t.Fatalf("%s SetBits offset:%d width:%d bit %d mistmatch", name, offset, width, bit)
+					}
+				}
+			}
+		}
+
+		if _, err := order.GetBits(make([]byte, 2), 9, 8); err == nil {
+			t.Errorf("%s: field past the end of the buffer must fail", name)
+		}
+		if _, err := order.GetBits(make([]byte, 2), ^uint(0)-3, 8); !errors.Is(err, bitwisebytes.ErrBufferTooShort) {
+			t.Errorf("%s: an offset wrapping around must fail with ErrBufferTooShort, got %v", name, err)
+		}
+		if _, err := order.GetBits(make([]byte, 16), 0, 65); err == nil {
+			t.Errorf("%s: width 65 must fail", name)
+		}
+		if err := order.SetBits(make([]byte, 2), 0, 4, 0x10); err == nil {
+			t.Errorf("%s: value wider than the field must fail", name)
+		}
+	}
+}
+
+func TestPutUintOverwrites(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		randUint := rand.Uint64()
+
+		bytesSlice := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+		bitwisebytes.LittleEndian.PutUint40(bytesSlice, randUint)
+		if r := bitwisebytes.LittleEndian.Uint64(bytesSlice); r != randUint&0xFFFFFFFFFF|0xFFFFFF<<40 {
+			t.Errorf("LittleEndian mistmatch: 0x%X", r)
+		}
+
+		bytesSlice = []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+		bitwisebytes.BigEndian.PutUint24(bytesSlice[5:], uint32(randUint))
+		if r := bitwisebytes.BigEndian.Uint64(bytesSlice); r != randUint&0xFFFFFF|0xFFFFFFFFFF<<24 {
+			t.Errorf("BigEndian mistmatch: 0x%X", r)
+		}
+	}
+}
+
+func TestClearPutShifted(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		randOffset := rand.Intn(8)
+
+		// 24 bit field over a buffer full of ones
+		bytesSlice := []byte{0xFF, 0xFF, 0xFF, 0xFF}
+		randUint24 := uint32(rand.Intn(0xFFFFFF))
+		bitwisebytes.LittleEndian.ClearPutUint24ShiftedBytes(randOffset, bytesSlice, randUint24)
+		expected := uint32(randUint24)<<uint(randOffset) | ^(uint32(0xFFFFFF) << uint(randOffset))
+		if r := bitwisebytes.LittleEndian.Uint32(bytesSlice); r != expected {
+			t.Errorf("LittleEndian mistmatch: 0x%X != 0x%X offset:%d", r, expected, randOffset)
+		}
+
+		bytesSlice = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+		bitwisebytes.BigEndian.ClearPutUint24ShiftedBytes(randOffset, bytesSlice, randUint24)
+		if r := bitwisebytes.BigEndian.Uint32(bytesSlice); r != expected {
+			t.Errorf("BigEndian mistmatch: 0x%X != 0x%X offset:%d", r, expected, randOffset)
+		}
+
+		// 64 bit field over random contents
+		original := make([]byte, 9)
+		rand.Read(original)
+		randUint64 := rand.Uint64()
+		orders := []struct {
+			order    fieldOrder
+			clearPut func(int, []byte, uint64)
+		}{
+			{bitwisebytes.LittleEndian, bitwisebytes.LittleEndian.ClearPutUint64ShiftedBytes},
+			{bitwisebytes.BigEndian, bitwisebytes.BigEndian.ClearPutUint64ShiftedBytes},
+		}
+		for _, o := range orders {
+			order := o.order
+			bytesSlice = append([]byte(nil), original...)
+			o.clearPut(randOffset, bytesSlice, randUint64)
+			if r, _ := order.GetBits(bytesSlice, uint(randOffset), 64); r != randUint64 {
+				t.Errorf("field mistmatch: 0x%X != 0x%X offset:%d", r, randUint64, randOffset)
+			}
+			if randOffset > 0 {
+				low, _ := order.GetBits(bytesSlice, 0, uint(randOffset))
+				origLow, _ := order.GetBits(original, 0, uint(randOffset))
+				if low != origLow {
+					t.Errorf("bits below the field changed: 0x%X != 0x%X", low, origLow)
+				}
+			}
+			high, _ := order.GetBits(bytesSlice, uint(randOffset)+64, uint(8-randOffset))
+			origHigh, _ := order.GetBits(original, uint(randOffset)+64, uint(8-randOffset))
+			if high != origHigh {
+				t.Errorf("bits above the field changed: 0x%X != 0x%X", high, origHigh)
+			}
+		}
+	}
+}
+
+var benchUint = make([]byte, 8)
+
+func BenchmarkUint32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.LittleEndian.Uint32(benchUint)
+	}
+}
+
+func BenchmarkUint64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.BigEndian.Uint64(benchUint)
+	}
+}
+
+func BenchmarkPutUint32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.LittleEndian.PutUint32(benchUint, uint32(i))
+	}
+}
+
+func BenchmarkPutUint40(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.BigEndian.PutUint40(benchUint, uint64(i))
+	}
+}
+
+func BenchmarkUint24ShiftedBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.LittleEndian.Uint24ShiftedBytes(-1, 3, benchUint[:4])
+	}
+}
+
+func BenchmarkPutUint24ShiftedBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.LittleEndian.PutUint24ShiftedBytes(3, benchUint[:4], uint32(i))
+	}
+}
@@ -0,0 +1,136 @@
+package bitwisebytes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// BitOrder selects which bit of each byte of a stream comes first
+type BitOrder int
+
+const (
+	// MSBFirst streams bit 7 of every byte first
+	MSBFirst BitOrder = iota
+	// LSBFirst streams bit 0 of every byte first
+	LSBFirst
+)
+
+// BitReader reads a stream of bits from an io.Reader. Only the bytes
+// needed to serve the current request are buffered.
+type BitReader struct {
+	r      *bufio.Reader
+	order  BitOrder
+	bitOff uint   // bits already consumed from the first buffered byte
+	pos    uint64 // bits consumed since the start of the stream
+}
+
+// NewBitReader returns a BitReader that reads r in the given bit order
+func NewBitReader(r io.Reader, order BitOrder) *BitReader {
+	return &BitReader{r: bufio.NewReader(r), order: order}
+}
+
+// Position returns the number of bits consumed so far
+func (br *BitReader) Position() uint64 {
+	return br.pos
+}
+
+// peek returns the next n bits without consuming them
+func (br *BitReader) peek(n uint) (uint64, error) {
+	if n > 64 {
+		return 0, fmt.Errorf("cannot read %d bits at once", n)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	need := int((br.bitOff + n + 7) / 8)
+	p, err := br.r.Peek(need)
+	if len(p) < need {
+		if err == io.EOF && len(p) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+
+	var v uint64
+	off, accumulated := br.bitOff, uint(0)
+	for i := 0; accumulated < n; i++ {
+		take := 8 - off
+		if take > n-accumulated {
+			take = n - accumulated
+		}
+		if br.order == MSBFirst {
+			bits := uint64(p[i]>>(8-off-take)) & (1<<take - 1)
+			v = v<<take | bits
+		} else {
+			bits := uint64(p[i]>>off) & (1<<take - 1)
+			v |= bits << accumulated
+		}
+		accumulated += take
+		off = 0
+	}
+	return v, nil
+}
+
+// consume drops n bits that are known to be buffered
+func (br *BitReader) consume(n uint) {
+	br.r.Discard(int((br.bitOff + n) / 8))
+	br.bitOff = (br.bitOff + n) % 8
+	br.pos += uint64(n)
+}
+
+// PeekBits returns the next n bits, up to 64, without consuming them. In
+// MSBFirst order the first bit of the stream is the MSB of the result, in
+// LSBFirst order it is the LSB.
+func (br *BitReader) PeekBits(n uint) (uint64, error) {
+	return br.peek(n)
+}
+
+// ReadBits consumes and returns the next n bits, up to 64. Nothing is
+// consumed if the stream ends before n bits are available.
+func (br *BitReader) ReadBits(n uint) (uint64, error) {
+	v, err := br.peek(n)
+	if err != nil {
+		return 0, err
+	}
+	br.consume(n)
+	return v, nil
+}
+
+// ReadBit consumes and returns the next bit
+func (br *BitReader) ReadBit() (bool, error) {
+	v, err := br.ReadBits(1)
+	return v == 1, err
+}
+
+// SkipBits consumes n bits without decoding them
+func (br *BitReader) SkipBits(n uint64) error {
+	// Finish the current byte so whole bytes can be discarded
+	if rest := uint64(8-br.bitOff) % 8; rest > 0 && n >= rest {
+		br.consume(uint(rest))
+		n -= rest
+	}
+	if whole := n / 8; whole > 0 {
+		discarded, err := br.r.Discard(int(whole))
+		br.pos += uint64(discarded) * 8
+		if err != nil {
+			if err == io.EOF && discarded > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	if _, err := br.peek(uint(n % 8)); err != nil {
+		return err
+	}
+	br.consume(uint(n % 8))
+	return nil
+}
+
+// AlignToByte drops the unread bits of the current byte so that the next
+// read starts on a byte boundary
+func (br *BitReader) AlignToByte() {
+	if br.bitOff != 0 {
+		br.consume(8 - br.bitOff)
+	}
+}
@@ -0,0 +1,95 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+// streamBit returns bit bitNum of the stream b in the given order
+func streamBit(b []byte, bitNum uint64, order bitwisebytes.BitOrder) uint64 {
+	shift := bitNum % 8
+	if order == bitwisebytes.MSBFirst {
+		shift = 7 - shift
+	}
+	return uint64(b[bitNum/8]>>shift) & 1
+}
+
+func TestBitReader(t *testing.T) {
+	for _, order := range []bitwisebytes.BitOrder{bitwisebytes.MSBFirst, bitwisebytes.LSBFirst} {
+		for i := 0; i < testLooops; i++ {
+			stream := make([]byte, rand.Intn(200)+16)
+			rand.Read(stream)
+			br := bitwisebytes.NewBitReader(bytes.NewReader(stream), order)
+
+			for {
+				pos := br.Position()
+				left := uint64(len(stream))*8 - pos
+				n := uint(rand.Intn(65))
+				if uint64(n) > left {
+					break
+				}
+
+				var v uint64
+				var err error
+				switch rand.Intn(4) {
+				case 0:
+					if err = br.SkipBits(uint64(n)); err != nil {
+						t.Fatal(err.Error())
+					}
+					if br.Position() != pos+uint64(n) {
+						t.Fatalf("position %d after skipping %d from %d", br.Position(), n, pos)
+					}
+					continue
+				case 1:
+					if v, err = br.PeekBits(n); br.Position() != pos {
+						t.Fatal("PeekBits must not consume")
+					}
+				default:
+					v, err = br.ReadBits(n)
+				}
+				if err != nil {
+					t.Fatal(err.Error())
+				}
+
+				for bit := uint(0); bit < n; bit++ {
+					expected := streamBit(stream, pos+uint64(bit), order)
+					got := (v >> bit) & 1
+					if order == bitwisebytes.MSBFirst {
+						got = (v >> (n - 1 - bit)) & 1
+					}
+					if got != expected {
+						t.Fatalf("order:%d pos:%d n:%d bit %d mistmatch", order, pos, n, bit)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBitReaderAlignAndEOF(t *testing.T) {
+	br := bitwisebytes.NewBitReader(bytes.NewReader([]byte{0xA5, 0x0F}), bitwisebytes.MSBFirst)
+
+	if bit, _ := br.ReadBit(); !bit {
+		t.Error("first bit of 0xA5 should be set")
+	}
+	br.AlignToByte()
+	if br.Position() != 8 {
+		t.Errorf("position should be 8, got %d", br.Position())
+	}
+	if _, err := br.ReadBits(12); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if v, err := br.ReadBits(8); err != nil || v != 0x0F {
+		t.Errorf("expected 0x0F, got 0x%X %v", v, err)
+	}
+	if _, err := br.ReadBit(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if err := br.SkipBits(1); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
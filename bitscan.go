@@ -0,0 +1,125 @@
+package bitwisebytes
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// ------------------------------------------------------------------
+//                   Bit counting and scanning
+// ------------------------------------------------------------------
+// All of these work on 64 bits words, see loadWord64. Bit indexes passed
+// to and returned by FindFirstSet and FindNextClear count from the least
+// significant bit of b[0], like the bit offsets of a little endian buffer.
+
+// bigEndianChunk returns the value of up to 8 bytes read in big endian order
+func bigEndianChunk(chunk []byte) (word uint64) {
+	if len(chunk) == 8 {
+		return binary.BigEndian.Uint64(chunk)
+	}
+	for _, aByte := range chunk {
+		word = word<<8 | uint64(aByte)
+	}
+	return word
+}
+
+// OnesCount returns the number of bits set in b
+func OnesCount(b []byte) (count int) {
+	for i := 0; i*8 < len(b); i++ {
+		count += bits.OnesCount64(loadWord64(b, i))
+	}
+	return count
+}
+
+// LeadingZeros returns the number of zero bits above the most significant
+// set bit of b laid out in the given order, len(b)*8 when no bit is set
+func LeadingZeros(b []byte, order ByteOrder) int {
+	totalBits := len(b) * 8
+	if order == BigEndian {
+		for start := 0; start < len(b); start += 8 {
+			end := start + 8
+			if end > len(b) {
+				end = len(b)
+			}
+			if word := bigEndianChunk(b[start:end]); word != 0 {
+				return end*8 - bits.Len64(word)
+			}
+		}
+		return totalBits
+	}
+	for i := (len(b)+7)/8 - 1; i >= 0; i-- {
+		if word := loadWord64(b, i); word != 0 {
+			return totalBits - i*64 - bits.Len64(word)
+		}
+	}
+	return totalBits
+}
+
+// TrailingZeros returns the number of zero bits below the least significant
+// set bit of b laid out in the given order, len(b)*8 when no bit is set
+func TrailingZeros(b []byte, order ByteOrder) int {
+	totalBits := len(b) * 8
+	if order == BigEndian {
+		for end := len(b); end > 0; end -= 8 {
+			start := end - 8
+			if start < 0 {
+				start = 0
+			}
+			if word := bigEndianChunk(b[start:end]); word != 0 {
+				return (len(b)-end)*8 + bits.TrailingZeros64(word)
+			}
+		}
+		return totalBits
+	}
+	for i := 0; i*8 < len(b); i++ {
+		if word := loadWord64(b, i); word != 0 {
+			return i*64 + bits.TrailingZeros64(word)
+		}
+	}
+	return totalBits
+}
+
+// FindFirstSet returns the index of the first set bit of b at or after bit
+// from, -1 when there is none
+func FindFirstSet(b []byte, from uint) int {
+	if from >= uint(len(b))*8 {
+		return -1
+	}
+	i := int(from / 64)
+	word := loadWord64(b, i) &^ (1<<(from%64) - 1)
+	for {
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word)
+		}
+		i++
+		if i*8 >= len(b) {
+			return -1
+		}
+		word = loadWord64(b, i)
+	}
+}
+
+// FindNextClear returns the index of the first clear bit of b at or after
+// bit from, -1 when there is none
+func FindNextClear(b []byte, from uint) int {
+	totalBits := len(b) * 8
+	if from >= uint(totalBits) {
+		return -1
+	}
+	i := int(from / 64)
+	word := ^loadWord64(b, i) &^ (1<<(from%64) - 1)
+	for {
+		if word != 0 {
+			// The bits past the end of b read as zero, ignore them
+			if index := i*64 + bits.TrailingZeros64(word); index < totalBits {
+				return index
+			}
+			return -1
+		}
+		i++
+		if i*8 >= len(b) {
+			return -1
+		}
+		word = ^loadWord64(b, i)
+	}
+}
@@ -0,0 +1,89 @@
+package bitwisebytes_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+// bitAt returns bit i of a little endian slice of bytes
+func bitAt(b []byte, i int) bool {
+	return b[i/8]>>uint(i%8)&1 == 1
+}
+
+func TestBitScan(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(30))
+		rand.Read(inputSlice)
+		// Sparse buffers exercise the word skipping paths
+		for j := range inputSlice {
+			if rand.Intn(4) != 0 {
+				inputSlice[j] = 0
+			}
+		}
+		if rand.Intn(4) == 0 {
+			for j := range inputSlice {
+				inputSlice[j] = ^inputSlice[j]
+			}
+		}
+		totalBits := len(inputSlice) * 8
+
+		reversed := make([]byte, len(inputSlice))
+		for j, aByte := range inputSlice {
+			reversed[len(inputSlice)-1-j] = aByte
+		}
+
+		count, lowest, highest := 0, -1, -1
+		for j := 0; j < totalBits; j++ {
+			if bitAt(inputSlice, j) {
+				count++
+				if lowest < 0 {
+					lowest = j
+				}
+				highest = j
+			}
+		}
+		leading, trailing := totalBits-1-highest, lowest
+		if lowest < 0 {
+			leading, trailing = totalBits, totalBits
+		}
+
+		if r := bitwisebytes.OnesCount(inputSlice); r != count {
+			t.Fatalf("OnesCount mistmatch: %d != %d", r, count)
+		}
+		if r := bitwisebytes.LeadingZeros(inputSlice, bitwisebytes.LittleEndian); r != leading {
+			t.Fatalf("LeadingZeros %X mistmatch: %d != %d", inputSlice, r, leading)
+		}
+		if r := bitwisebytes.LeadingZeros(reversed, bitwisebytes.BigEndian); r != leading {
+			t.Fatalf("BigEndian LeadingZeros %X mistmatch: %d != %d", reversed, r, leading)
+		}
+		if r := bitwisebytes.TrailingZeros(inputSlice, bitwisebytes.LittleEndian); r != trailing {
+			t.Fatalf("TrailingZeros %X mistmatch: %d != %d", inputSlice, r, trailing)
+		}
+		if r := bitwisebytes.TrailingZeros(reversed, bitwisebytes.BigEndian); r != trailing {
+			t.Fatalf("BigEndian TrailingZeros %X mistmatch: %d != %d", reversed, r, trailing)
+		}
+
+		from := rand.Intn(totalBits + 9)
+		nextSet, nextClear := -1, -1
+		for j := from; j < totalBits; j++ {
+			if bitAt(inputSlice, j) && nextSet < 0 {
+				nextSet = j
+			}
+			if !bitAt(inputSlice, j) && nextClear < 0 {
+				nextClear = j
+			}
+		}
+		if r := bitwisebytes.FindFirstSet(inputSlice, uint(from)); r != nextSet {
+			t.Fatalf("FindFirstSet %X from %d mistmatch: %d != %d", inputSlice, from, r, nextSet)
+		}
+		if r := bitwisebytes.FindNextClear(inputSlice, uint(from)); r != nextClear {
+			t.Fatalf("FindNextClear %X from %d mistmatch: %d != %d", inputSlice, from, r, nextClear)
+		}
+	}
+
+	if r := bitwisebytes.FindNextClear([]byte{0xFF, 0xFF, 0xFF}, 0); r != -1 {
+		t.Errorf("a full bitmap has no clear bit, got %d", r)
+	}
+}
@@ -0,0 +1,135 @@
+package bitwisebytes
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// Bitset is a fixed length set of bits stored in a little endian slice of
+// bytes: bit i is bit i%8 of byte i/8, which is bit i%BitsWordSize of word
+// i/BitsWordSize of the ByteSliceToWordSlice layout. The bits of the last
+// byte past Len are ignored. Indexes past Len make the methods panic, like
+// out of range slice indexes do.
+type Bitset struct {
+	b      []byte
+	length uint
+}
+
+// NewBitset returns an empty Bitset of length bits
+func NewBitset(length uint) *Bitset {
+	return &Bitset{b: make([]byte, (length+7)/8), length: length}
+}
+
+// BitsetFromBytes returns a Bitset of len(b)*8 bits backed by the little
+// endian slice of bytes b, writes to one show in the other.
+func BitsetFromBytes(b []byte) *Bitset {
+	return &Bitset{b: b, length: uint(len(b)) * 8}
+}
+
+// Bytes returns the (Len+7)/8 bytes little endian slice of bytes backing
+// bs, writes to one show in the other. Bits written past Len are ignored by
+// bs and cleared by Union, Intersect and Difference.
+func (bs *Bitset) Bytes() []byte {
+	return bs.b
+}
+
+// Len returns the number of bits of bs
+func (bs *Bitset) Len() uint {
+	return bs.length
+}
+
+// word returns the i-th 64 bits word of bs, without the bits past Len
+func (bs *Bitset) word(i int) uint64 {
+	word := loadWord64(bs.b, i)
+	if tail := bs.length - uint(i)*64; tail < 64 {
+		word &= 1<<tail - 1
+	}
+	return word
+}
+
+// Count returns the number of bits set in bs
+func (bs *Bitset) Count() (count int) {
+	for i := 0; i*8 < len(bs.b); i++ {
+		count += bits.OnesCount64(bs.word(i))
+	}
+	return count
+}
+
+func (bs *Bitset) checkIndex(i uint) {
+	if i >= bs.length {
+		panic(fmt.Sprintf("bitwisebytes: Bitset index %d out of range [0:%d]", i, bs.length))
+	}
+}
+
+// Test reports whether bit i is set
+func (bs *Bitset) Test(i uint) bool {
+	bs.checkIndex(i)
+	return bs.b[i/8]>>(i%8)&1 == 1
+}
+
+// Set sets bit i
+func (bs *Bitset) Set(i uint) {
+	bs.checkIndex(i)
+	bs.b[i/8] |= 1 << (i % 8)
+}
+
+// Clear clears bit i
+func (bs *Bitset) Clear(i uint) {
+	bs.checkIndex(i)
+	bs.b[i/8] &^= 1 << (i % 8)
+}
+
+// Flip inverts bit i
+func (bs *Bitset) Flip(i uint) {
+	bs.checkIndex(i)
+	bs.b[i/8] ^= 1 << (i % 8)
+}
+
+// SetRange sets the bits from, inclusive, to to, exclusive
+func (bs *Bitset) SetRange(from, to uint) {
+	if from > to || to > bs.length {
+		panic(fmt.Sprintf("bitwisebytes: Bitset range [%d:%d] out of range [0:%d]", from, to, bs.length))
+	}
+	SetRange(bs.b, from, to)
+}
+
+// setOp stores op(bs word, other word) in every word of bs
+func (bs *Bitset) setOp(other *Bitset, op func(x, y uint64) uint64) (err error) {
+	if bs.length != other.length {
+		return fmt.Errorf("bitsets must be of the same length")
+	}
+	for i := 0; i*8 < len(bs.b); i++ {
+		storeWord64(bs.b, i, op(bs.word(i), other.word(i)))
+	}
+	return err
+}
+
+// Union adds to bs the bits set in other
+func (bs *Bitset) Union(other *Bitset) (err error) {
+	return bs.setOp(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersect clears in bs the bits not set in other
+func (bs *Bitset) Intersect(other *Bitset) (err error) {
+	return bs.setOp(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference clears in bs the bits set in other
+func (bs *Bitset) Difference(other *Bitset) (err error) {
+	return bs.setOp(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// All returns an iterator over the indexes of the bits set in bs, in
+// increasing order
+func (bs *Bitset) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i := 0; i*8 < len(bs.b); i++ {
+			for word := bs.word(i); word != 0; word &= word - 1 {
+				if !yield(uint(i)*64 + uint(bits.TrailingZeros64(word))) {
+					return
+				}
+			}
+		}
+	}
+}
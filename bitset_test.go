@@ -0,0 +1,131 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestBitset(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		length := uint(rand.Intn(300) + 1)
+		bs := bitwisebytes.NewBitset(length)
+		reference := make([]bool, length)
+
+		for j := 0; j < 200; j++ {
+			index := uint(rand.Intn(int(length)))
+			switch rand.Intn(3) {
+			case 0:
+				bs.Set(index)
+				reference[index] = true
+			case 1:
+				bs.Clear(index)
+				reference[index] = false
+			case 2:
+				bs.Flip(index)
+				reference[index] = !reference[index]
+			}
+		}
+		from := uint(rand.Intn(int(length) + 1))
+		to := from + uint(rand.Intn(int(length-from)+1))
+		bs.SetRange(from, to)
+		for j := from; j < to; j++ {
+			reference[j] = true
+		}
+
+		var expected []uint
+		for j, isSet := range reference {
+			if bs.Test(uint(j)) != isSet {
+				t.Fatalf("bit %d mistmatch", j)
+			}
+			if isSet {
+				expected = append(expected, uint(j))
+			}
+		}
+		if bs.Len() != length || bs.Count() != len(expected) {
+			t.Fatalf("Len %d Count %d mistmatch: %d %d", bs.Len(), bs.Count(), length, len(expected))
+		}
+		if r := slices.Collect(bs.All()); !slices.Equal(r, expected) {
+			t.Fatalf("All mistmatch:\n%v\n%v", r, expected)
+		}
+
+		// The byte view uses the package little endian layout
+		byteView := bs.Bytes()
+		if len(byteView) != int(length+7)/8 || bitwisebytes.OnesCount(byteView) != len(expected) {
+			t.Fatalf("Bytes mistmatch: %X", byteView)
+		}
+		if len(expected) > 0 && bitwisebytes.FindFirstSet(byteView, 0) != int(expected[0]) {
+			t.Fatalf("Bytes layout mistmatch: %X", byteView)
+		}
+	}
+}
+
+func TestBitsetSetOps(t *testing.T) {
+	a := make([]byte, 24)
+	b := make([]byte, 24)
+	rand.Read(a)
+	rand.Read(b)
+
+	for _, c := range []struct {
+		name string
+		op   func(x, y *bitwisebytes.Bitset) error
+		ref  func(x, y []byte) error
+	}{
+		{"Union", (*bitwisebytes.Bitset).Union, bitwisebytes.Or},
+		{"Intersect", (*bitwisebytes.Bitset).Intersect, bitwisebytes.And},
+		{"Difference", (*bitwisebytes.Bitset).Difference, bitwisebytes.AndNot},
+	} {
+		x := bitwisebytes.BitsetFromBytes(append([]byte(nil), a...))
+		if err := c.op(x, bitwisebytes.BitsetFromBytes(b)); err != nil {
+			t.Fatal(err.Error())
+		}
+		expected := append([]byte(nil), a...)
+		c.ref(expected, b)
+		if !bytes.Equal(x.Bytes(), expected) {
+			t.Errorf("%s mistmatch: %X != %X", c.name, x.Bytes(), expected)
+		}
+	}
+
+	if err := bitwisebytes.NewBitset(8).Union(bitwisebytes.NewBitset(9)); err == nil {
+		t.Errorf("expected an error on bitsets of different lengths")
+	}
+}
+
+func TestBitsetSharesBytes(t *testing.T) {
+	bs := bitwisebytes.NewBitset(70)
+	byteView := bs.Bytes()
+	byteView[8] = 0x02
+	if !bs.Test(65) {
+		t.Errorf("writes to Bytes must show in the Bitset")
+	}
+
+	// Bits written past Len are ignored and cleared by the set operations
+	byteView[8] |= 0xC0
+	if bs.Count() != 1 || !slices.Equal(slices.Collect(bs.All()), []uint{65}) {
+		t.Errorf("bits past Len must be ignored: %v", slices.Collect(bs.All()))
+	}
+	if err := bs.Union(bitwisebytes.NewBitset(70)); err != nil || byteView[8] != 0x02 {
+		t.Errorf("Union must clear the bits past Len: %X %v", byteView, err)
+	}
+
+	// A slice of any length is shared, not copied
+	odd := []byte{0x01, 0x80, 0x00}
+	fromOdd := bitwisebytes.BitsetFromBytes(odd)
+	if r := slices.Collect(fromOdd.All()); !slices.Equal(r, []uint{0, 15}) {
+		t.Errorf("unexpected bits %v", r)
+	}
+	fromOdd.Set(17)
+	if odd[2] != 0x02 {
+		t.Errorf("writes to the Bitset must show in the slice: %X", odd)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic on an out of range index")
+		}
+	}()
+	bs.Set(70)
+}
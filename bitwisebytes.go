@@ -3,9 +3,8 @@ package bitwisebytes
 import (
 	"fmt"
 	"encoding/binary"
-	"math"
+	"math/bits"
 	"unsafe"
-	log "github.com/sirupsen/logrus"
 )
 
 //BytesWordSize holds the size in bytes of a uint word
@@ -15,108 +14,439 @@ const BitsWordSize = BytesWordSize *8
 
 //ShiftLeft shifts a slice of bytes shiftCount bits to the left
 func ShiftLeft(inputBuffer []byte, shiftCount uint) (outputBuffer []byte, err error) {
-	bitsShift := shiftCount % BitsWordSize
-	wordsShift := shiftCount / BitsWordSize
+	outputBuffer = make([]byte, len(inputBuffer))
+	err = ShiftLeftInto(outputBuffer, inputBuffer, shiftCount)
+	return outputBuffer, err
+}
 
-	widthInBytes := uint(len(inputBuffer))
+//ShiftRight shifts a slice of bytes shiftCount bits to the right
+func ShiftRight(inputBuffer []byte, shiftCount uint) (outputBuffer []byte, err error) {
 	outputBuffer = make([]byte, len(inputBuffer))
+	err = ShiftRightInto(outputBuffer, inputBuffer, shiftCount)
+	return outputBuffer, err
+}
 
-	widthInWords := uint(widthInBytes / BytesWordSize)
-	modulus := widthInBytes % BytesWordSize
+//ShiftLeftExtend shifts a slice of bytes shiftCount bits to the left growing
+// the result, when needed, so that no set bit is lost
+func ShiftLeftExtend(inputBuffer []byte, shiftCount uint) (outputBuffer []byte) {
+	widthInBytes := uint(len(inputBuffer))
+	// A zero input stays zero whatever the shift, it never grows
+	usedBits := significantBits(inputBuffer)
+	if neededBytes := (usedBits + shiftCount + 7) / 8; usedBits > 0 && neededBytes > widthInBytes {
+		widthInBytes = neededBytes
+	}
+	outputBuffer = make([]byte, widthInBytes)
+	copy(outputBuffer, inputBuffer)
 
-	if modulus != 0 {
-		widthInWords++
+	// Same lengths, the shift cannot fail
+	_ = ShiftLeftInto(outputBuffer, outputBuffer, shiftCount)
+	return outputBuffer
+}
+
+//significantBits returns the position of the most significant set bit of a
+// little endian slice of bytes plus one, 0 when no bit is set
+func significantBits(inputBuffer []byte) uint {
+	for i := len(inputBuffer) - 1; i >= 0; i-- {
+		if inputBuffer[i] != 0 {
+			return uint(i)*8 + uint(bits.Len8(inputBuffer[i]))
+		}
 	}
+	return 0
+}
 
-	carry := uint(0)
+//Trim drops the most significant zero bytes of a slice of bytes laid out in
+// the given order, those at the end for LittleEndian and at the start for
+// BigEndian. The result shares the memory of the input.
+func Trim(inputBuffer []byte, order ByteOrder) (outputBuffer []byte) {
+	if order == BigEndian {
+		for i, aByte := range inputBuffer {
+			if aByte != 0 {
+				return inputBuffer[i:]
+			}
+		}
+		return inputBuffer[len(inputBuffer):]
+	}
+	return inputBuffer[:(significantBits(inputBuffer)+7)/8]
+}
 
-	// --------------------------------------------------
-	// Convert the slice of bytes into a slice of words
-	// ---------------------------------------------------
-	sliceOfWords := ByteSliceToWordSlice(inputBuffer)
+//ShiftLeftCarry shifts a slice of bytes shiftCount bits to the left like
+// ShiftLeft, filling the vacated low bits with the shiftCount least
+// significant bits of fillIn (zeros when fillIn is nil). The bits shifted
+// out at the top are returned in carryOut, its bit 0 being the lowest of
+// them, so it can be fed as fillIn to the next, more significant, buffer.
+func ShiftLeftCarry(inputBuffer []byte, shiftCount uint, fillIn []byte) (outputBuffer, carryOut []byte, err error) {
+	fill, err := carryFill(inputBuffer, shiftCount, fillIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalBits := uint(len(inputBuffer)) * 8
 
-	tmpWordsBuffer := make([]uint, widthInWords)  // here is where shifted words will be tmp saved
+	// The top shiftCount bits end up at the bottom of carryOut
+	carryOut, err = ShiftRight(inputBuffer, totalBits-shiftCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	carryOut = carryOut[:(shiftCount+7)/8]
 
-	// ---------------------------------------------------------
-	// Itereate through the slice of words and shift each word
-	// Store the shifted word in the tmp slice
-	// --------------------------------------------------------
-	for i , word := range sliceOfWords {
+	outputBuffer, err = ShiftLeft(inputBuffer, shiftCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputBuffer, carryOut, Or(outputBuffer, fill)
+}
 
-		shiftedWord := (uint(word) << bitsShift) | carry
-		carry = uint(word)
-		carryMask := uint(math.Pow(2,float64(bitsShift))) - 1
-		maskShift := uint(BitsWordSize - bitsShift)
-		carryMask = carryMask << maskShift
-		carry = (carry & carryMask) >> uint(BitsWordSize -bitsShift)
-		tmpWordsBuffer[i] = shiftedWord //| (carry & carryMask)
+//ShiftRightCarry shifts a slice of bytes shiftCount bits to the right like
+// ShiftRight, filling the vacated high bits with the shiftCount least
+// significant bits of fillIn (zeros when fillIn is nil). The bits shifted
+// out at the bottom are returned in carryOut, so it can be fed as fillIn to
+// the next, less significant, buffer.
+func ShiftRightCarry(inputBuffer []byte, shiftCount uint, fillIn []byte) (outputBuffer, carryOut []byte, err error) {
+	fill, err := carryFill(inputBuffer, shiftCount, fillIn)
+	if err != nil {
+		return nil, nil, err
 	}
-	// ---------------------------
-	// Now do the Words shifting
-	// --------------------------
-	outputBufferWords := ShiftWordsSliceLeft(tmpWordsBuffer, int(wordsShift))
+	totalBits := uint(len(inputBuffer)) * 8
 
-	// ---------------------------------------------------------------------
-	// Convert the final slice of words into its final representation as a
-	// slice of bytes
-	// --------------------------------------------------------------------
-	outputBuffer = WordSliceToByteSlice(outputBufferWords)
+	carryOut = make([]byte, (shiftCount+7)/8)
+	copy(carryOut, inputBuffer)
+	clearBits(carryOut, shiftCount, uint(len(carryOut))*8-shiftCount)
 
-	return outputBuffer[0:widthInBytes] , err
+	// The fill goes to the top shiftCount bits
+	if err = ShiftLeftInto(fill, fill, totalBits-shiftCount); err != nil {
+		return nil, nil, err
+	}
+	outputBuffer, err = ShiftRight(inputBuffer, shiftCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outputBuffer, carryOut, Or(outputBuffer, fill)
 }
 
-//ShiftRight shifts a slice of bytes shiftCount bits to the right
-func ShiftRight(inputBuffer []byte, shiftCount uint) (outputBuffer []byte, err error) {
-	bitsShift := shiftCount % BitsWordSize
-	wordsShift := shiftCount / BitsWordSize
+//carryFill returns the shiftCount least significant bits of fillIn in a
+// slice as long as inputBuffer
+func carryFill(inputBuffer []byte, shiftCount uint, fillIn []byte) (fill []byte, err error) {
+	totalBits := uint(len(inputBuffer)) * 8
+	if shiftCount > totalBits {
+		return nil, fmt.Errorf("shift count %d exceeds the %d bits of the input", shiftCount, totalBits)
+	}
+	fill = make([]byte, len(inputBuffer))
+	if fillIn == nil {
+		return fill, err
+	}
+	fillBytes := (shiftCount + 7) / 8
+	if uint(len(fillIn)) < fillBytes {
+		return nil, fmt.Errorf("fill of %d bytes too short for %d bits", len(fillIn), shiftCount)
+	}
+	copy(fill, fillIn[:fillBytes])
+	clearBits(fill, shiftCount, totalBits-shiftCount)
+	return fill, err
+}
 
-	widthInBytes := uint(len(inputBuffer))
+//ShiftLeftOrder shifts a slice of bytes laid out in the given order
+// shiftCount bits to the left, towards its most significant byte
+func ShiftLeftOrder(order ByteOrder, inputBuffer []byte, shiftCount uint) (outputBuffer []byte, err error) {
+	if order != BigEndian {
+		return ShiftLeft(inputBuffer, shiftCount)
+	}
+	outputBuffer = reverseBytes(inputBuffer)
+	if err = ShiftLeftInto(outputBuffer, outputBuffer, shiftCount); err != nil {
+		return nil, err
+	}
+	reverseBytesInPlace(outputBuffer)
+	return outputBuffer, err
+}
+
+//ShiftRightOrder shifts a slice of bytes laid out in the given order
+// shiftCount bits to the right, towards its least significant byte
+func ShiftRightOrder(order ByteOrder, inputBuffer []byte, shiftCount uint) (outputBuffer []byte, err error) {
+	if order != BigEndian {
+		return ShiftRight(inputBuffer, shiftCount)
+	}
+	outputBuffer = reverseBytes(inputBuffer)
+	if err = ShiftRightInto(outputBuffer, outputBuffer, shiftCount); err != nil {
+		return nil, err
+	}
+	reverseBytesInPlace(outputBuffer)
+	return outputBuffer, err
+}
+
+//reverseBytesInPlace reverses the order of the bytes of b
+func reverseBytesInPlace(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+//ShiftRightArithmetic shifts the widthBits least significant bits of a slice
+// of bytes shiftCount bits to the right, filling the vacated bits with the
+// sign bit (bit widthBits-1). Bits above widthBits are left untouched.
+// A widthBits of 0 shifts the whole slice.
+func ShiftRightArithmetic(inputBuffer []byte, shiftCount uint, widthBits uint) (outputBuffer []byte, err error) {
+	totalBits := uint(len(inputBuffer)) * 8
+	if widthBits == 0 {
+		widthBits = totalBits
+	}
+	if widthBits > totalBits {
+		return nil, fmt.Errorf("shift width %d exceeds the %d bits of the input", widthBits, totalBits)
+	}
+	if widthBits == 0 {
+		return []byte{}, err
+	}
+	if shiftCount > widthBits {
+		shiftCount = widthBits
+	}
+	signBit := (inputBuffer[(widthBits-1)/8] >> ((widthBits - 1) % 8)) & 1
+
+	// --------------------------------------------
+	// Shift the field alone so that the bits above
+	// it do not leak into it
+	// --------------------------------------------
 	outputBuffer = make([]byte, len(inputBuffer))
+	copy(outputBuffer, inputBuffer)
+	clearBits(outputBuffer, widthBits, totalBits-widthBits)
+	if err = ShiftRightInto(outputBuffer, outputBuffer, shiftCount); err != nil {
+		return nil, err
+	}
 
-	widthInWords := uint(widthInBytes / BytesWordSize)
-	modulus := widthInBytes % BytesWordSize
+	// -------------------------------------------
+	// Replicate the sign into the vacated bits and
+	// restore the bits above the field
+	// -------------------------------------------
+	if signBit == 1 {
+		if err = Or(outputBuffer, MakeMask(uint(len(inputBuffer)), shiftCount, widthBits-shiftCount)); err != nil {
+			return nil, err
+		}
+	}
+	upperBits := make([]byte, len(inputBuffer))
+	copy(upperBits, inputBuffer)
+	clearBits(upperBits, 0, widthBits)
+	return outputBuffer, Or(outputBuffer, upperBits)
+}
 
-	if modulus != 0 {
-		widthInWords++
+//ShiftLeftInto stores src shifted shiftCount bits to the left in dst without
+// allocating. dst and src must be of the same length and may be the same slice.
+func ShiftLeftInto(dst, src []byte, shiftCount uint) (err error) {
+	if len(dst) != len(src) {
+		return fmt.Errorf("dst and src must be of the same length")
+	}
+	if shiftCount >= uint(len(src))*8 {
+		clearBytes(dst)
+		return err
+	}
+	wordsShift := int(shiftCount / 64)
+	bitsShift := shiftCount % 64
+
+	// ------------------------------------------------------------
+	// Every output word takes its high bits from an input word and
+	// its bitsShift low bits from the one below it. Rotating both
+	// puts those bits in place, so each input word is loaded and
+	// rotated once. Walk down from the top word so that dst may
+	// alias src, every output word only depends on input words at
+	// or below it.
+	// ------------------------------------------------------------
+	lowMask := uint64(1)<<bitsShift - 1
+	top := (len(src)+7)/8 - 1
+	upper := bits.RotateLeft64(loadWord64(src, top-wordsShift), int(bitsShift))
+	for i := top; i >= 0; i-- {
+		lower := bits.RotateLeft64(loadWord64(src, i-wordsShift-1), int(bitsShift))
+		storeWord64(dst, i, upper&^lowMask|lower&lowMask)
+		upper = lower
+	}
+	return err
+}
+
+//ShiftRightInto stores src shifted shiftCount bits to the right in dst without
+// allocating. dst and src must be of the same length and may be the same slice.
+func ShiftRightInto(dst, src []byte, shiftCount uint) (err error) {
+	if len(dst) != len(src) {
+		return fmt.Errorf("dst and src must be of the same length")
+	}
+	if shiftCount >= uint(len(src))*8 {
+		clearBytes(dst)
+		return err
+	}
+	wordsShift := int(shiftCount / 64)
+	bitsShift := shiftCount % 64
+
+	// ------------------------------------------------------------
+	// Mirror of ShiftLeftInto: rotate right and take the low bits
+	// of each output word from an input word and its bitsShift high
+	// bits from the one above it. Walk up from the bottom word so
+	// that dst may alias src, every output word only depends on
+	// input words at or above it.
+	// ------------------------------------------------------------
+	lowMask := ^uint64(0) >> bitsShift
+	words := (len(src) + 7) / 8
+	lower := bits.RotateLeft64(loadWord64(src, wordsShift), -int(bitsShift))
+	for i := 0; i < words; i++ {
+		upper := bits.RotateLeft64(loadWord64(src, i+wordsShift+1), -int(bitsShift))
+		storeWord64(dst, i, lower&lowMask|upper&^lowMask)
+		lower = upper
+	}
+	return err
+}
+
+//loadWord64 returns the i-th little endian 64 bits word of b, the bytes past
+// the end of b read as zero
+func loadWord64(b []byte, i int) (word uint64) {
+	if i < 0 {
+		return 0
 	}
+	return loadBytes64(b, i*8)
+}
 
-	carry := uint(0)
+//loadBytes64 returns the little endian 64 bits word starting at byte start
+// of b, the bytes past the end of b read as zero
+func loadBytes64(b []byte, start int) uint64 {
+	if start >= len(b) {
+		return 0
+	}
+	b = b[start:]
+	n := len(b)
+	switch {
+	case n >= 8:
+		return binary.LittleEndian.Uint64(b)
+	case n >= 4:
+		// A short tail is read as two overlapping loads
+		return uint64(binary.LittleEndian.Uint32(b)) | uint64(binary.LittleEndian.Uint32(b[n-4:]))<<(8*n-32)
+	case n >= 2:
+		return uint64(binary.LittleEndian.Uint16(b)) | uint64(binary.LittleEndian.Uint16(b[n-2:]))<<(8*n-16)
+	}
+	return uint64(b[0])
+}
 
-	// --------------------------------------------------
-	// Convert the slice of bytes into a slice of words
-	// ---------------------------------------------------
-	sliceOfWords := ByteSliceToWordSlice(inputBuffer)
+//storeWord64 stores word as the i-th little endian 64 bits word of b, the
+// bytes past the end of b are dropped
+func storeWord64(b []byte, i int, word uint64) {
+	storeBytes64(b, i*8, word)
+}
 
-	tmpWordsBuffer := make([]uint, widthInWords)  // here is where shifted words will be tmp saved
+//storeBytes64 stores word as the little endian 64 bits word starting at
+// byte start of b, the bytes past the end of b are dropped
+func storeBytes64(b []byte, start int, word uint64) {
+	if start >= len(b) {
+		return
+	}
+	b = b[start:]
+	n := len(b)
+	switch {
+	case n >= 8:
+		binary.LittleEndian.PutUint64(b, word)
+	case n >= 4:
+		// A short tail is written as two overlapping stores
+		binary.LittleEndian.PutUint32(b[n-4:], uint32(word>>(8*n-32)))
+		binary.LittleEndian.PutUint32(b, uint32(word))
+	case n >= 2:
+		b[n-1] = byte(word >> (8*n - 8))
+		binary.LittleEndian.PutUint16(b, uint16(word))
+	default:
+		b[0] = byte(word)
+	}
+}
 
-	// ---------------------------------------------------------
-	// Itereate through the slice of words and shift each word
-	// Store the shifted word in the tmp slice
-	// --------------------------------------------------------
-	//for i , word := range sliceOfWords {
-	for i := len(sliceOfWords) -1 ; i>=0 ;i-- {
-		word := sliceOfWords[i]
-		shiftedWord := (uint(word) >> bitsShift) | carry
-		tmpWordsBuffer[i] = shiftedWord
-		carry = uint(word)
-		carryMask := uint(math.Pow(2,float64(bitsShift))) - 1
-		carryShift := uint(BitsWordSize - bitsShift)
-		carry = (carry & carryMask) << carryShift
+//loadReversed64 is loadBytes64 for a big endian slice of bytes: it returns
+// the 64 bits word whose least significant byte is b[len(b)-1-start], the
+// bytes before the start of b read as zero
+func loadReversed64(b []byte, start int) uint64 {
+	n := len(b) - start
+	switch {
+	case n >= 8:
+		return binary.BigEndian.Uint64(b[n-8:])
+	case n >= 4:
+		// A short head is read as two overlapping loads
+		return uint64(binary.BigEndian.Uint32(b[n-4:])) | uint64(binary.BigEndian.Uint32(b))<<(8*n-32)
+	case n >= 2:
+		return uint64(binary.BigEndian.Uint16(b[n-2:])) | uint64(binary.BigEndian.Uint16(b))<<(8*n-16)
+	case n == 1:
+		return uint64(b[0])
 	}
-	// ---------------------------
-	// Now do the Words shifting
-	// --------------------------
+	return 0
+}
 
-	outputBufferWords := ShiftWordsSliceRight(tmpWordsBuffer, int(wordsShift))
+//storeReversed64 is storeBytes64 for a big endian slice of bytes, the bytes
+// before the start of b are dropped
+func storeReversed64(b []byte, start int, word uint64) {
+	n := len(b) - start
+	switch {
+	case n >= 8:
+		binary.BigEndian.PutUint64(b[n-8:], word)
+	case n >= 4:
+		// A short head is written as two overlapping stores
+		binary.BigEndian.PutUint32(b, uint32(word>>(8*n-32)))
+		binary.BigEndian.PutUint32(b[n-4:], uint32(word))
+	case n >= 2:
+		binary.BigEndian.PutUint16(b, uint16(word>>(8*n-16)))
+		binary.BigEndian.PutUint16(b[n-2:], uint16(word))
+	case n == 1:
+		b[0] = byte(word)
+	}
+}
 
-	// ---------------------------------------------------------------------
-	// Convert the final slice of words into its final representation as a
-	// slice of bytes
-	// --------------------------------------------------------------------
+func clearBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
 
-	outputBuffer = WordSliceToByteSlice(outputBufferWords)
+//RotateLeft rotates the widthBits least significant bits of a slice of bytes
+// rotateCount bits to the left, bits above widthBits are left untouched.
+// A widthBits of 0 rotates the whole slice.
+func RotateLeft(inputBuffer []byte, rotateCount uint, widthBits uint) (outputBuffer []byte, err error) {
+	totalBits := uint(len(inputBuffer)) * 8
+	if widthBits == 0 {
+		widthBits = totalBits
+	}
+	if widthBits > totalBits {
+		return nil, fmt.Errorf("rotation width %d exceeds the %d bits of the input", widthBits, totalBits)
+	}
+	if widthBits == 0 {
+		return []byte{}, err
+	}
+	rotateCount %= widthBits
+
+	// ---------------------------------------------
+	// Split the input into the rotated field and
+	// the untouched bits above it
+	// ---------------------------------------------
+	field := make([]byte, len(inputBuffer))
+	copy(field, inputBuffer)
+	clearBits(field, widthBits, totalBits-widthBits)
 
-	return outputBuffer[0:widthInBytes] , err
+	outputBuffer = make([]byte, len(inputBuffer))
+	copy(outputBuffer, inputBuffer)
+	clearBits(outputBuffer, 0, widthBits)
+
+	// -------------------------------------------------------
+	// The bits shifted out at the top come back at the bottom
+	// -------------------------------------------------------
+	leftPart, err := ShiftLeft(field, rotateCount)
+	if err != nil {
+		return nil, err
+	}
+	clearBits(leftPart, widthBits, totalBits-widthBits)
+
+	rightPart, err := ShiftRight(field, widthBits-rotateCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Or(outputBuffer, leftPart); err != nil {
+		return nil, err
+	}
+	return outputBuffer, Or(outputBuffer, rightPart)
+}
+
+//RotateRight rotates the widthBits least significant bits of a slice of bytes
+// rotateCount bits to the right, bits above widthBits are left untouched.
+// A widthBits of 0 rotates the whole slice.
+func RotateRight(inputBuffer []byte, rotateCount uint, widthBits uint) (outputBuffer []byte, err error) {
+	if widthBits == 0 {
+		widthBits = uint(len(inputBuffer)) * 8
+	}
+	if widthBits == 0 {
+		return []byte{}, err
+	}
+	return RotateLeft(inputBuffer, widthBits-rotateCount%widthBits, widthBits)
 }
 
 //ByteSliceToWordSlice converts a slice of bytes into a slice of words
@@ -148,44 +478,22 @@ func ByteSliceToWordSlice(inputBytes []byte) (outputWords []uint) {
 			end = widthInBytes
 		}
 
-		// ------------------------------------------
-		// Create temporal byte slice that will store
-		// the bytes to be converted to 1 word
-		// ------------------------------------------
-		tmpSlice := make([]byte, BytesWordSize)
-
-		// Copy the corresponding bytes to the tmp slice
-		for i, aByte := range inputBytes[start:end] {
-			tmpSlice[i] = aByte
-		}
-
 		// -----------------------------------------
 		// Do the slice of bytes to word conversion
 		// -----------------------------------------
-		var word uint
-		switch BytesWordSize {
-		case 8:
-			word = uint(binary.LittleEndian.Uint64(tmpSlice))
-		case 4:
-			word = uint(binary.LittleEndian.Uint32(tmpSlice))
-		default:
-			log.Panic("Word size of %d no supported!!", BytesWordSize)
-		}
-		outputWords[i] = word
+		outputWords[i] = uint(loadWord64(inputBytes[start:end], 0))
 	}
 		return outputWords
 }
 
 //BytesSliceToWordSlice converts a slice of words into a slice of bytes
 func WordSliceToByteSlice(inputWords []uint) (outputBytes []byte) {
-	outputBytes = make([]byte,0,uint(len(inputWords))*BytesWordSize)
-
-	for _ , word := range inputWords {
-		// Create subslice here
-		tmpBuf := make([]byte, BytesWordSize)
-		binary.LittleEndian.PutUint64(tmpBuf,uint64(word))
-		outputBytes = append(outputBytes,tmpBuf...)
+	outputBytes = make([]byte, uint(len(inputWords))*BytesWordSize)
 
+	for i, word := range inputWords {
+		// Store the word straight into its subslice
+		start := uint(i) * BytesWordSize
+		storeWord64(outputBytes[start:start+BytesWordSize], 0, uint64(word))
 	}
 	return outputBytes
 }
@@ -217,27 +525,149 @@ func ShiftWordsSliceRight(buff []uint, shiftWords int) (returnBuff []uint) {
 }
 
 
-func And(inputOutput []byte, operand []byte) (err error){
-	if len(inputOutput) != len(operand) {
-		return fmt.Errorf("input and operand must of of the same lenth")
+//binaryKind selects what binaryOp stores in dst
+type binaryKind int
+
+const (
+	binaryAnd binaryKind = iota
+	binaryOr
+	binaryXor
+	binaryAndNot
+	binaryNand
+	binaryNor
+	binaryXnor
+)
+
+//binaryOp stores a[i] op b[i] in dst[i], all the slices must be of the same
+// length. Every op has its own loop so there is no call per byte.
+func binaryOp(dst, a, b []byte, kind binaryKind) (err error) {
+	if len(a) != len(b) || len(dst) != len(a) {
+		return fmt.Errorf("input and operand must be of the same length")
 	}
-	for i , op := range  operand {
-		inputOutput[i] = inputOutput[i] & op
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	switch kind {
+	case binaryAnd:
+		for i, x := range a {
+			dst[i] = x & b[i]
+		}
+	case binaryOr:
+		for i, x := range a {
+			dst[i] = x | b[i]
+		}
+	case binaryXor:
+		for i, x := range a {
+			dst[i] = x ^ b[i]
+		}
+	case binaryAndNot:
+		for i, x := range a {
+			dst[i] = x &^ b[i]
+		}
+	case binaryNand:
+		for i, x := range a {
+			dst[i] = ^(x & b[i])
+		}
+	case binaryNor:
+		for i, x := range a {
+			dst[i] = ^(x | b[i])
+		}
+	case binaryXnor:
+		for i, x := range a {
+			dst[i] = ^(x ^ b[i])
+		}
 	}
 	return err
 }
 
+//And stores inputOutput AND operand in inputOutput
+func And(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryAnd)
+}
+
+//Or stores inputOutput OR operand in inputOutput
+func Or(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryOr)
+}
+
+//Xor stores inputOutput XOR operand in inputOutput
+func Xor(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryXor)
+}
+
+//AndNot clears in inputOutput the bits set in operand
+func AndNot(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryAndNot)
+}
+
+//Nand stores NOT (inputOutput AND operand) in inputOutput
+func Nand(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryNand)
+}
+
+//Nor stores NOT (inputOutput OR operand) in inputOutput
+func Nor(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryNor)
+}
+
+//Xnor stores NOT (inputOutput XOR operand) in inputOutput
+func Xnor(inputOutput []byte, operand []byte) (err error) {
+	return binaryOp(inputOutput, inputOutput, operand, binaryXnor)
+}
 
-func Or(inputOutput []byte, operand []byte) (err error){
-	if len(inputOutput) != len(operand) {
-		return fmt.Errorf("input and operand must of of the same lenth")
+//Not inverts every bit of inputOutput
+func Not(inputOutput []byte) {
+	for i, x := range inputOutput {
+		inputOutput[i] = ^x
 	}
-	for i , op := range  operand {
-		inputOutput[i] = inputOutput[i] | op
+}
+
+//AndInto stores a AND b in dst leaving a and b untouched
+func AndInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryAnd)
+}
+
+//OrInto stores a OR b in dst leaving a and b untouched
+func OrInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryOr)
+}
+
+//XorInto stores a XOR b in dst leaving a and b untouched
+func XorInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryXor)
+}
+
+//AndNotInto stores a AND NOT b in dst leaving a and b untouched
+func AndNotInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryAndNot)
+}
+
+//NandInto stores NOT (a AND b) in dst leaving a and b untouched
+func NandInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryNand)
+}
+
+//NorInto stores NOT (a OR b) in dst leaving a and b untouched
+func NorInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryNor)
+}
+
+//XnorInto stores NOT (a XOR b) in dst leaving a and b untouched
+func XnorInto(dst, a, b []byte) (err error) {
+	return binaryOp(dst, a, b, binaryXnor)
+}
+
+//NotInto stores NOT src in dst leaving src untouched
+func NotInto(dst, src []byte) (err error) {
+	if len(dst) != len(src) {
+		return fmt.Errorf("input and operand must be of the same length")
+	}
+	for i, x := range src {
+		dst[i] = ^x
 	}
 	return err
 }
 
+//MakeMask returns a size bytes slice with width bits set starting at bit offset
 func MakeMask(size uint, width uint, offset uint) (outputMask []byte) {
 
 	maskWords := size / BytesWordSize
@@ -245,29 +675,130 @@ func MakeMask(size uint, width uint, offset uint) (outputMask []byte) {
 	if modulus >0 {
 		maskWords ++
 	}
-	wordsSlice := make([]uint,maskWords)
-	widthModulus := width % BitsWordSize
-
-	for i, _ := range wordsSlice {
-		if uint(i) == (maskWords - 1) {
-			if widthModulus > 0 {
-				wordsSlice[i] = uint(math.Pow(2,float64(widthModulus))) - 1
-			}else{
-				wordsSlice[i] = uint(math.Pow(2,float64(BitsWordSize))) - 1
-			}
-		}else {
-			wordsSlice[i] = uint(math.Pow(2,float64(BitsWordSize))) - 1
+	wordsSlice := make([]uint, maskWords)
+
+	// ------------------------------------------------------
+	// Fill whole words with ones until width bits are set,
+	// the word holding the last bits gets a partial mask
+	// ------------------------------------------------------
+	for i := range wordsSlice {
+		wordStart := uint(i) * BitsWordSize
+		switch {
+		case width >= wordStart+BitsWordSize:
+			wordsSlice[i] = ^uint(0)
+		case width > wordStart:
+			wordsSlice[i] = uint(1)<<(width-wordStart) - 1
 		}
 	}
 	outputMask = WordSliceToByteSlice(wordsSlice)
 
-	if offset > 0 {
-		var err error
-		outputMask , err = ShiftLeft(outputMask,offset)
-		if err != nil {
-			log.Panic(err.Error())
+	// Both slices have the same length so the shift cannot fail
+	_ = ShiftLeftInto(outputMask, outputMask, offset)
+
+	return outputMask[0:size]
+}
+
+//CheckedMakeMask is MakeMask returning ErrBufferTooShort when the mask bits
+// do not fit in size bytes, instead of silently dropping them
+func CheckedMakeMask(size uint, width uint, offset uint) (outputMask []byte, err error) {
+	if offset > size*8 || width > size*8-offset {
+		return nil, fieldError("MakeMask", offset, width, ErrBufferTooShort)
+	}
+	return MakeMask(size, width, offset), err
+}
+
+//rangeKind selects what rangeOp does to the bits of the range
+type rangeKind int
+
+const (
+	rangeSet rangeKind = iota
+	rangeClear
+	rangeFlip
+)
+
+//rangeOp sets, clears or flips the bits of the range [from, to) of b, one
+// 64 bits word at a time
+func rangeOp(opName string, b []byte, from, to uint, kind rangeKind) (err error) {
+	if from > to {
+		return fieldError(opName, from, 0, ErrOffsetOutOfRange)
+	}
+	if to > uint(len(b))*8 {
+		return fieldError(opName, from, to-from, ErrBufferTooShort)
+	}
+	for from < to {
+		shift := from % 64
+		n := 64 - shift
+		if n > to-from {
+			n = to - from
+		}
+		mask := ^uint64(0)
+		if n < 64 {
+			mask = (1<<n - 1) << shift
+		}
+		i := int(from / 64)
+		word := loadWord64(b, i)
+		switch kind {
+		case rangeSet:
+			word |= mask
+		case rangeClear:
+			word &^= mask
+		case rangeFlip:
+			word ^= mask
 		}
+		storeWord64(b, i, word)
+		from += n
 	}
+	return err
+}
 
-	return outputMask[0:size]
-}
\ No newline at end of file
+//clearBits zeroes the width bits field found at bitOffset of a little
+// endian slice of bytes, bits past the end of b are ignored
+func clearBits(b []byte, bitOffset, width uint) {
+	end := uint(len(b)) * 8
+	if bitOffset >= end {
+		return
+	}
+	if width < end-bitOffset {
+		end = bitOffset + width
+	}
+	rangeOp("ClearRange", b, bitOffset, end, rangeClear)
+}
+
+//SetRange sets the bits from, inclusive, to to, exclusive, of a little
+// endian slice of bytes in place
+func SetRange(inputOutput []byte, from, to uint) (err error) {
+	return rangeOp("SetRange", inputOutput, from, to, rangeSet)
+}
+
+//ClearRange clears the bits from, inclusive, to to, exclusive, of a little
+// endian slice of bytes in place
+func ClearRange(inputOutput []byte, from, to uint) (err error) {
+	return rangeOp("ClearRange", inputOutput, from, to, rangeClear)
+}
+
+//FlipRange inverts the bits from, inclusive, to to, exclusive, of a little
+// endian slice of bytes in place
+func FlipRange(inputOutput []byte, from, to uint) (err error) {
+	return rangeOp("FlipRange", inputOutput, from, to, rangeFlip)
+}
+
+//MakeMaskRange returns a size bytes little endian mask with the bits lo,
+// inclusive, to hi, exclusive, set. Or several of them together, or apply
+// SetRange to the result, to build non contiguous masks.
+func MakeMaskRange(size uint, lo, hi uint) (outputMask []byte, err error) {
+	outputMask = make([]byte, size)
+	if err = SetRange(outputMask, lo, hi); err != nil {
+		return nil, err
+	}
+	return outputMask, err
+}
+
+//MakeMaskOrder is MakeMask for a slice of bytes laid out in the given order,
+// offset counts from the least significant bit of the least significant byte
+func MakeMaskOrder(order ByteOrder, size uint, width uint, offset uint) (outputMask []byte) {
+	outputMask = MakeMask(size, width, offset)
+	if order == BigEndian {
+		reverseBytesInPlace(outputMask)
+	}
+	return outputMask
+}
@@ -2,6 +2,8 @@ package bitwisebytes_test
 
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 	"os"
 	"github.com/lagarciag/bitwisebytes"
@@ -196,3 +198,432 @@ func TestShiftWordsSliceRightLeftLong(t *testing.T) {
 	}
 
 }
+
+func TestLogicOperations(t *testing.T) {
+	ops := []struct {
+		name    string
+		inPlace func(inputOutput, operand []byte) error
+		into    func(dst, a, b []byte) error
+		ref     func(x, y byte) byte
+	}{
+		{"And", bitwisebytes.And, bitwisebytes.AndInto, func(x, y byte) byte { return x & y }},
+		{"Or", bitwisebytes.Or, bitwisebytes.OrInto, func(x, y byte) byte { return x | y }},
+		{"Xor", bitwisebytes.Xor, bitwisebytes.XorInto, func(x, y byte) byte { return x ^ y }},
+		{"AndNot", bitwisebytes.AndNot, bitwisebytes.AndNotInto, func(x, y byte) byte { return x &^ y }},
+		{"Nand", bitwisebytes.Nand, bitwisebytes.NandInto, func(x, y byte) byte { return ^(x & y) }},
+		{"Nor", bitwisebytes.Nor, bitwisebytes.NorInto, func(x, y byte) byte { return ^(x | y) }},
+		{"Xnor", bitwisebytes.Xnor, bitwisebytes.XnorInto, func(x, y byte) byte { return ^(x ^ y) }},
+	}
+
+	a := make([]byte, rand.Intn(64)+1)
+	b := make([]byte, len(a))
+	rand.Read(a)
+	rand.Read(b)
+	aCopy := append([]byte(nil), a...)
+	bCopy := append([]byte(nil), b...)
+
+	for _, op := range ops {
+		dst := make([]byte, len(a))
+		if err := op.into(dst, a, b); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(a, aCopy) || !bytes.Equal(b, bCopy) {
+			t.Fatalf("%sInto modified its inputs", op.name)
+		}
+
+		inPlace := append([]byte(nil), a...)
+		if err := op.inPlace(inPlace, b); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		for i := range a {
+			if dst[i] != op.ref(a[i], b[i]) || inPlace[i] != dst[i] {
+				t.Errorf("%s mistmatch at byte %d", op.name, i)
+			}
+		}
+
+		if err := op.inPlace(inPlace, b[1:]); err == nil {
+			t.Errorf("%s must fail on different lengths", op.name)
+		}
+		if err := op.into(dst[1:], a, b); err == nil {
+			t.Errorf("%sInto must fail on different lengths", op.name)
+		}
+	}
+
+	notDst := make([]byte, len(a))
+	if err := bitwisebytes.NotInto(notDst, a); err != nil {
+		t.Fatal(err.Error())
+	}
+	bitwisebytes.Not(a)
+	for i := range a {
+		if a[i] != ^aCopy[i] || notDst[i] != a[i] {
+			t.Errorf("Not mistmatch at byte %d", i)
+		}
+	}
+}
+
+// refRotateLeft rotates the width low bits of b one bit at a time
+func refRotateLeft(b []byte, n, width uint) []byte {
+	out := append([]byte(nil), b...)
+	bit := func(buf []byte, i uint) byte { return (buf[i/8] >> (i % 8)) & 1 }
+	for i := uint(0); i < width; i++ {
+		j := (i + n) % width
+		out[j/8] = out[j/8]&^(1<<(j%8)) | bit(b, i)<<(j%8)
+	}
+	return out
+}
+
+func TestRotate(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(40)+1)
+		rand.Read(inputSlice)
+		inputCopy := append([]byte(nil), inputSlice...)
+
+		width := uint(rand.Intn(len(inputSlice)*8) + 1)
+		n := uint(rand.Intn(int(width) * 2))
+
+		left, err := bitwisebytes.RotateLeft(inputSlice, n, width)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if expected := refRotateLeft(inputSlice, n, width); !bytes.Equal(left, expected) {
+			t.Fatalf("RotateLeft n:%d width:%d\n%X\n%X", n, width, left, expected)
+		}
+
+		right, err := bitwisebytes.RotateRight(left, n, width)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(right, inputSlice) || !bytes.Equal(inputSlice, inputCopy) {
+			t.Fatalf("RotateRight n:%d width:%d did not undo RotateLeft", n, width)
+		}
+
+		full, _ := bitwisebytes.RotateLeft(inputSlice, n, 0)
+		if expected := refRotateLeft(inputSlice, n, uint(len(inputSlice))*8); !bytes.Equal(full, expected) {
+			t.Fatalf("full width RotateLeft n:%d mistmatch", n)
+		}
+	}
+
+	if _, err := bitwisebytes.RotateLeft([]byte{1}, 1, 9); err == nil {
+		t.Error("width wider than the input must fail")
+	}
+}
+
+// refShiftLeft shifts the little endian integer b one bit at a time
+func refShiftLeft(b []byte, n uint) []byte {
+	out := make([]byte, len(b))
+	for i := uint(0); i+n < uint(len(b))*8; i++ {
+		j := i + n
+		out[j/8] |= ((b[i/8] >> (i % 8)) & 1) << (j % 8)
+	}
+	return out
+}
+
+func TestShiftInto(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(40))
+		rand.Read(inputSlice)
+		n := uint(rand.Intn(len(inputSlice)*8 + 70))
+
+		expected := refShiftLeft(inputSlice, n)
+		outputSlice := make([]byte, len(inputSlice))
+		if err := bitwisebytes.ShiftLeftInto(outputSlice, inputSlice, n); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(outputSlice, expected) {
+			t.Fatalf("ShiftLeftInto n:%d\n%X\n%X", n, outputSlice, expected)
+		}
+		if allocated, _ := bitwisebytes.ShiftLeft(inputSlice, n); !bytes.Equal(allocated, expected) {
+			t.Fatalf("ShiftLeft n:%d\n%X\n%X", n, allocated, expected)
+		}
+
+		// Shifting back right only loses the bits shifted out at the top
+		inPlace := append([]byte(nil), outputSlice...)
+		if err := bitwisebytes.ShiftRightInto(inPlace, inPlace, n); err != nil {
+			t.Fatal(err.Error())
+		}
+		if reShifted := refShiftLeft(inPlace, n); !bytes.Equal(reShifted, outputSlice) {
+			t.Fatalf("ShiftRightInto n:%d\n%X\n%X", n, reShifted, outputSlice)
+		}
+		if allocated, _ := bitwisebytes.ShiftRight(outputSlice, n); !bytes.Equal(allocated, inPlace) {
+			t.Fatalf("ShiftRight n:%d\n%X\n%X", n, allocated, inPlace)
+		}
+	}
+
+	if err := bitwisebytes.ShiftLeftInto(make([]byte, 2), make([]byte, 3), 1); err == nil {
+		t.Error("different lengths must fail")
+	}
+}
+
+var benchSlice = make([]byte, 4096)
+
+func BenchmarkShiftLeft(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.ShiftLeft(benchSlice, 13)
+	}
+}
+
+func BenchmarkShiftLeftInto(b *testing.B) {
+	dst := make([]byte, len(benchSlice))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.ShiftLeftInto(dst, benchSlice, 13)
+	}
+}
+
+func BenchmarkShiftRight(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.ShiftRight(benchSlice, 13)
+	}
+}
+
+func BenchmarkAnd(b *testing.B) {
+	inputOutput := make([]byte, len(benchSlice))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.And(inputOutput, benchSlice)
+	}
+}
+
+func BenchmarkShiftRightInto(b *testing.B) {
+	dst := make([]byte, len(benchSlice))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.ShiftRightInto(dst, benchSlice, 13)
+	}
+}
+
+func TestMakeMask(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		size := uint(rand.Intn(40) + 1)
+		width := uint(rand.Intn(int(size) * 8))
+		offset := uint(rand.Intn(int(size)*8 - int(width) + 1))
+
+		mask := bitwisebytes.MakeMask(size, width, offset)
+		if uint(len(mask)) != size {
+			t.Fatalf("mask of %d bytes, expected %d", len(mask), size)
+		}
+		for bit := uint(0); bit < size*8; bit++ {
+			expected := bit >= offset && bit < offset+width
+			if (mask[bit/8]>>(bit%8))&1 == 1 != expected {
+				t.Fatalf("size:%d width:%d offset:%d bit %d mistmatch %X", size, width, offset, bit, mask)
+			}
+		}
+	}
+}
+
+func TestShiftRightArithmetic(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(20)+1)
+		rand.Read(inputSlice)
+		width := uint(rand.Intn(len(inputSlice)*8) + 1)
+		n := uint(rand.Intn(int(width) + 8))
+
+		outputSlice, err := bitwisebytes.ShiftRightArithmetic(inputSlice, n, width)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		bit := func(b []byte, i uint) byte { return (b[i/8] >> (i % 8)) & 1 }
+		sign := bit(inputSlice, width-1)
+		for j := uint(0); j < uint(len(inputSlice))*8; j++ {
+			expected := bit(inputSlice, j)
+			if j < width {
+				expected = sign
+				if j+n < width {
+					expected = bit(inputSlice, j+n)
+				}
+			}
+			if bit(outputSlice, j) != expected {
+				t.Fatalf("n:%d width:%d bit %d mistmatch\n%X\n%X", n, width, j, inputSlice, outputSlice)
+			}
+		}
+	}
+
+	// -4 >> 1 == -2 over 12 bits, the upper nibble is kept
+	outputSlice, _ := bitwisebytes.ShiftRightArithmetic([]byte{0xFC, 0xAF}, 1, 12)
+	if !bytes.Equal(outputSlice, []byte{0xFE, 0xAF}) {
+		t.Errorf("unexpected result %X", outputSlice)
+	}
+}
+
+func TestShiftCarry(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		// Shifting two chained buffers must match shifting their concatenation
+		low := make([]byte, rand.Intn(20)+1)
+		high := make([]byte, rand.Intn(20)+1)
+		rand.Read(low)
+		rand.Read(high)
+		whole := append(append([]byte(nil), low...), high...)
+
+		n := uint(rand.Intn(len(low)*8 + 1))
+		if uint(len(high))*8 < n {
+			n = uint(len(high)) * 8
+		}
+
+		expected, _ := bitwisebytes.ShiftLeft(whole, n)
+		lowOut, carry, err := bitwisebytes.ShiftLeftCarry(low, n, nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		highOut, _, err := bitwisebytes.ShiftLeftCarry(high, n, carry)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if got := append(lowOut, highOut...); !bytes.Equal(got, expected) {
+			t.Fatalf("ShiftLeftCarry n:%d\n%X\n%X", n, got, expected)
+		}
+
+		expected, _ = bitwisebytes.ShiftRight(whole, n)
+		highOut, carry, err = bitwisebytes.ShiftRightCarry(high, n, nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		lowOut, _, err = bitwisebytes.ShiftRightCarry(low, n, carry)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if got := append(lowOut, highOut...); !bytes.Equal(got, expected) {
+			t.Fatalf("ShiftRightCarry n:%d\n%X\n%X", n, got, expected)
+		}
+	}
+
+	// Fill with ones
+	ones := []byte{0xFF, 0xFF}
+	outputSlice, carry, _ := bitwisebytes.ShiftLeftCarry([]byte{0x81, 0x00}, 4, ones)
+	if !bytes.Equal(outputSlice, []byte{0x1F, 0x08}) || !bytes.Equal(carry, []byte{0x00}) {
+		t.Errorf("unexpected ShiftLeftCarry result %X %X", outputSlice, carry)
+	}
+	outputSlice, carry, _ = bitwisebytes.ShiftRightCarry([]byte{0x8B, 0x00}, 4, ones)
+	if !bytes.Equal(outputSlice, []byte{0x08, 0xF0}) || !bytes.Equal(carry, []byte{0x0B}) {
+		t.Errorf("unexpected ShiftRightCarry result %X %X", outputSlice, carry)
+	}
+
+	if _, _, err := bitwisebytes.ShiftLeftCarry([]byte{1}, 9, nil); err == nil {
+		t.Error("shift wider than the input must fail")
+	}
+	if _, _, err := bitwisebytes.ShiftRightCarry([]byte{1, 2}, 9, []byte{1}); err == nil {
+		t.Error("short fill must fail")
+	}
+}
+
+func TestShiftLeftExtend(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(20)+1)
+		rand.Read(inputSlice)
+		n := uint(rand.Intn(100))
+
+		outputSlice := bitwisebytes.ShiftLeftExtend(inputSlice, n)
+		if len(outputSlice) < len(inputSlice) {
+			t.Fatalf("result shrunk to %d bytes", len(outputSlice))
+		}
+
+		// No bit is lost, shifting back gives the input back
+		back, _ := bitwisebytes.ShiftRight(outputSlice, n)
+		if !bytes.Equal(back[:len(inputSlice)], inputSlice) || len(bitwisebytes.Trim(back, bitwisebytes.LittleEndian)) > len(inputSlice) {
+			t.Fatalf("n:%d\n%X\n%X", n, inputSlice, outputSlice)
+		}
+		// And it is not wider than needed
+		if len(outputSlice) > len(inputSlice) && outputSlice[len(outputSlice)-1] == 0 {
+			t.Fatalf("n:%d result has a useless top byte %X", n, outputSlice)
+		}
+	}
+
+	if outputSlice := bitwisebytes.ShiftLeftExtend([]byte{0x81}, 1); !bytes.Equal(outputSlice, []byte{0x02, 0x01}) {
+		t.Errorf("unexpected result %X", outputSlice)
+	}
+	if outputSlice := bitwisebytes.ShiftLeftExtend([]byte{0, 0}, 40); !bytes.Equal(outputSlice, []byte{0, 0}) {
+		t.Errorf("a zero input must not grow, got %X", outputSlice)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	if r := bitwisebytes.Trim([]byte{1, 2, 0, 0}, bitwisebytes.LittleEndian); !bytes.Equal(r, []byte{1, 2}) {
+		t.Errorf("unexpected LittleEndian trim %X", r)
+	}
+	if r := bitwisebytes.Trim([]byte{0, 0, 1, 0}, bitwisebytes.BigEndian); !bytes.Equal(r, []byte{1, 0}) {
+		t.Errorf("unexpected BigEndian trim %X", r)
+	}
+	if r := bitwisebytes.Trim([]byte{0, 0}, bitwisebytes.BigEndian); len(r) != 0 {
+		t.Errorf("all zero input should trim to nothing, got %X", r)
+	}
+}
+
+func TestShiftOrder(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(20)+1)
+		rand.Read(inputSlice)
+		n := uint(rand.Intn(len(inputSlice)*8 + 8))
+
+		// A big endian buffer is the reverse of its little endian twin
+		reversed := make([]byte, len(inputSlice))
+		for j, aByte := range inputSlice {
+			reversed[len(inputSlice)-1-j] = aByte
+		}
+
+		leLeft, _ := bitwisebytes.ShiftLeftOrder(bitwisebytes.LittleEndian, inputSlice, n)
+		beLeft, _ := bitwisebytes.ShiftLeftOrder(bitwisebytes.BigEndian, reversed, n)
+		leRight, _ := bitwisebytes.ShiftRightOrder(bitwisebytes.LittleEndian, inputSlice, n)
+		beRight, _ := bitwisebytes.ShiftRightOrder(bitwisebytes.BigEndian, reversed, n)
+		for j := range inputSlice {
+			if leLeft[j] != beLeft[len(inputSlice)-1-j] || leRight[j] != beRight[len(inputSlice)-1-j] {
+				t.Fatalf("n:%d byte %d mistmatch", n, j)
+			}
+		}
+	}
+
+	// 0x0180 << 3 == 0x0C00 in a big endian header
+	if r, _ := bitwisebytes.ShiftLeftOrder(bitwisebytes.BigEndian, []byte{0x01, 0x80}, 3); !bytes.Equal(r, []byte{0x0C, 0x00}) {
+		t.Errorf("unexpected result %X", r)
+	}
+	if r := bitwisebytes.MakeMaskOrder(bitwisebytes.BigEndian, 3, 12, 4); !bytes.Equal(r, []byte{0x00, 0xFF, 0xF0}) {
+		t.Errorf("unexpected mask %X", r)
+	}
+}
+
+func TestRangeOps(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		inputSlice := make([]byte, rand.Intn(30)+1)
+		rand.Read(inputSlice)
+		from := uint(rand.Intn(len(inputSlice)*8 + 1))
+		to := from + uint(rand.Intn(len(inputSlice)*8-int(from)+1))
+
+		mask, err := bitwisebytes.MakeMaskRange(uint(len(inputSlice)), from, to)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(mask, bitwisebytes.MakeMask(uint(len(inputSlice)), to-from, from)) {
+			t.Fatalf("from:%d to:%d MakeMaskRange mistmatch: %X", from, to, mask)
+		}
+
+		ops := []struct {
+			name  string
+			op    func([]byte, uint, uint) error
+			logic func([]byte, []byte) error
+		}{
+			{"SetRange", bitwisebytes.SetRange, bitwisebytes.Or},
+			{"ClearRange", bitwisebytes.ClearRange, bitwisebytes.AndNot},
+			{"FlipRange", bitwisebytes.FlipRange, bitwisebytes.Xor},
+		}
+		for _, o := range ops {
+			result := append([]byte(nil), inputSlice...)
+			expected := append([]byte(nil), inputSlice...)
+			if err := o.op(result, from, to); err != nil {
+				t.Fatal(err.Error())
+			}
+			o.logic(expected, mask)
+			if !bytes.Equal(result, expected) {
+				t.Fatalf("%s from:%d to:%d mistmatch:\n%X\n%X", o.name, from, to, result, expected)
+			}
+		}
+	}
+
+	if err := bitwisebytes.SetRange(make([]byte, 2), 4, 17); !errors.Is(err, bitwisebytes.ErrBufferTooShort) {
+		t.Errorf("expected ErrBufferTooShort, got %v", err)
+	}
+	if _, err := bitwisebytes.MakeMaskRange(2, 9, 3); !errors.Is(err, bitwisebytes.ErrOffsetOutOfRange) {
+		t.Errorf("expected ErrOffsetOutOfRange, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package bitwisebytes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// BitWriter writes a stream of bits to an io.Writer. Bits are gathered
+// into bytes and buffered, Flush must be called once writing is done.
+type BitWriter struct {
+	w      *bufio.Writer
+	order  BitOrder
+	padBit bool
+	cur    byte   // byte being filled
+	nbits  uint   // bits already placed in cur
+	count  uint64 // bits written since the start of the stream
+}
+
+// NewBitWriter returns a BitWriter that writes to w in the given bit order
+func NewBitWriter(w io.Writer, order BitOrder) *BitWriter {
+	return &BitWriter{w: bufio.NewWriter(w), order: order}
+}
+
+// SetPadBit selects the value of the bits Flush uses to complete the last
+// byte, zero by default
+func (bw *BitWriter) SetPadBit(bit bool) {
+	bw.padBit = bit
+}
+
+// BitsWritten returns the number of bits written so far, including the
+// padding added by Flush
+func (bw *BitWriter) BitsWritten() uint64 {
+	return bw.count
+}
+
+// WriteBits writes the n low bits of v, up to 64. In MSBFirst order bit
+// n-1 of v is written first, in LSBFirst order bit 0 is.
+func (bw *BitWriter) WriteBits(v uint64, n uint) error {
+	if n > 64 {
+		return fmt.Errorf("cannot write %d bits at once", n)
+	}
+	for n > 0 {
+		take := 8 - bw.nbits
+		if take > n {
+			take = n
+		}
+		if bw.order == MSBFirst {
+			chunk := (v >> (n - take)) & (1<<take - 1)
+			bw.cur |= byte(chunk << (8 - bw.nbits - take))
+		} else {
+			chunk := v & (1<<take - 1)
+			bw.cur |= byte(chunk << bw.nbits)
+			v >>= take
+		}
+		n -= take
+		bw.nbits += take
+		bw.count += uint64(take)
+
+		if bw.nbits == 8 {
+			if err := bw.w.WriteByte(bw.cur); err != nil {
+				return err
+			}
+			bw.cur, bw.nbits = 0, 0
+		}
+	}
+	return nil
+}
+
+// WriteBit writes a single bit
+func (bw *BitWriter) WriteBit(bit bool) error {
+	if bit {
+		return bw.WriteBits(1, 1)
+	}
+	return bw.WriteBits(0, 1)
+}
+
+// WriteBytesUnaligned writes the bytes of p at the current bit position,
+// which does not need to be on a byte boundary
+func (bw *BitWriter) WriteBytesUnaligned(p []byte) error {
+	if bw.nbits == 0 {
+		n, err := bw.w.Write(p)
+		bw.count += uint64(n) * 8
+		return err
+	}
+	for _, aByte := range p {
+		if err := bw.WriteBits(uint64(aByte), 8); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush completes the current byte with the pad bit and writes any
+// buffered data to the underlying io.Writer
+func (bw *BitWriter) Flush() error {
+	if bw.nbits > 0 {
+		if bw.padBit {
+			if bw.order == MSBFirst {
+				bw.cur |= byte(1)<<(8-bw.nbits) - 1
+			} else {
+				bw.cur |= ^byte(0) << bw.nbits
+			}
+		}
+		if err := bw.w.WriteByte(bw.cur); err != nil {
+			return err
+		}
+		bw.count += uint64(8 - bw.nbits)
+		bw.cur, bw.nbits = 0, 0
+	}
+	return bw.w.Flush()
+}
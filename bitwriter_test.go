@@ -0,0 +1,89 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestBitWriterRoundTrip(t *testing.T) {
+	for _, order := range []bitwisebytes.BitOrder{bitwisebytes.MSBFirst, bitwisebytes.LSBFirst} {
+		for i := 0; i < testLooops; i++ {
+			var buf bytes.Buffer
+			bw := bitwisebytes.NewBitWriter(&buf, order)
+
+			type chunk struct {
+				v     uint64
+				n     uint
+				bytes []byte
+			}
+			var chunks []chunk
+			var total uint64
+			for j := rand.Intn(50); j >= 0; j-- {
+				c := chunk{n: uint(rand.Intn(65))}
+				if rand.Intn(4) == 0 {
+					c.bytes = make([]byte, rand.Intn(10))
+					rand.Read(c.bytes)
+					if err := bw.WriteBytesUnaligned(c.bytes); err != nil {
+						t.Fatal(err.Error())
+					}
+					total += uint64(len(c.bytes)) * 8
+				} else {
+					// High bits past n must be ignored
+					c.v = rand.Uint64()
+					if err := bw.WriteBits(c.v, c.n); err != nil {
+						t.Fatal(err.Error())
+					}
+					if c.n < 64 {
+						c.v &= 1<<c.n - 1
+					}
+					total += uint64(c.n)
+				}
+				chunks = append(chunks, c)
+			}
+			if bw.BitsWritten() != total {
+				t.Fatalf("BitsWritten %d != %d", bw.BitsWritten(), total)
+			}
+			if err := bw.Flush(); err != nil {
+				t.Fatal(err.Error())
+			}
+			if uint64(buf.Len()) != (total+7)/8 || bw.BitsWritten() != uint64(buf.Len())*8 {
+				t.Fatalf("%d bytes and %d bits written for %d bits", buf.Len(), bw.BitsWritten(), total)
+			}
+
+			br := bitwisebytes.NewBitReader(&buf, order)
+			for _, c := range chunks {
+				if c.bytes != nil {
+					for _, aByte := range c.bytes {
+						if v, err := br.ReadBits(8); err != nil || byte(v) != aByte {
+							t.Fatalf("byte mistmatch: 0x%X != 0x%X %v", v, aByte, err)
+						}
+					}
+					continue
+				}
+				if v, err := br.ReadBits(c.n); err != nil || v != c.v {
+					t.Fatalf("order:%d n:%d mistmatch: 0x%X != 0x%X %v", order, c.n, v, c.v, err)
+				}
+			}
+		}
+	}
+}
+
+func TestBitWriterPadding(t *testing.T) {
+	var buf bytes.Buffer
+	bw := bitwisebytes.NewBitWriter(&buf, bitwisebytes.MSBFirst)
+	bw.SetPadBit(true)
+	bw.WriteBits(0x2, 3)
+	bw.Flush()
+
+	bw = bitwisebytes.NewBitWriter(&buf, bitwisebytes.LSBFirst)
+	bw.SetPadBit(true)
+	bw.WriteBits(0x2, 3)
+	bw.Flush()
+
+	if !bytes.Equal(buf.Bytes(), []byte{0x5F, 0xFA}) {
+		t.Errorf("unexpected padding: %X", buf.Bytes())
+	}
+}
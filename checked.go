@@ -0,0 +1,301 @@
+package bitwisebytes
+
+// ------------------------------------------------------------------
+//              Checked operations on/to shifted bytes
+// ------------------------------------------------------------------
+// The Checked methods mirror the ShiftedBytes accessors but return a
+// *FieldError instead of panicking on a bad offset, a buffer of the wrong
+// size or a value whose bits would be shifted out of the buffer. They are
+// the strict mode of the Field based shiftedGet and shiftedPut.
+
+// checkedBytesSliceGet reads len(mask) bytes starting at bit offset of b
+func checkedBytesSliceGet(op string, mask []byte, offset int, b []byte, bigEndian bool) ([]byte, error) {
+	width := uint(len(mask)) * 8
+	if offset < 0 || offset > 7 {
+		return nil, shiftedOffsetError(op, offset, width)
+	}
+	if uint(len(b))*8 < uint(offset)+width {
+		return nil, fieldError(op, uint(offset), width, ErrBufferTooShort)
+	}
+	returnBytes := make([]byte, len(mask))
+	for i := range mask {
+		// i counts bytes from the least significant one
+		j := i
+		if bigEndian {
+			j = len(mask) - 1 - i
+		}
+		aByte, err := getBits(b, uint(offset+8*i), 8, bigEndian)
+		if err != nil {
+			return nil, err
+		}
+		returnBytes[j] = byte(aByte) & mask[j]
+	}
+	return returnBytes, nil
+}
+
+// checkedBytesSlicePut ORs in into out starting at bit offset
+func checkedBytesSlicePut(op string, offset int, out, in []byte, bigEndian bool) error {
+	width := uint(len(in)) * 8
+	if offset < 0 || offset > 7 {
+		return shiftedOffsetError(op, offset, width)
+	}
+	if len(out) < len(in) {
+		return fieldError(op, uint(offset), width, ErrBufferTooShort)
+	}
+
+	// Validate everything before touching out
+	room := uint(len(out))*8 - uint(offset)
+	if room < width {
+		top := in[len(in)-1]
+		if bigEndian {
+			top = in[0]
+		}
+		if top>>(8-(width-room)) != 0 {
+			return fieldError(op, uint(offset), width, ErrValueOverflow)
+		}
+	}
+
+	for i := range in {
+		aByte := in[i]
+		if bigEndian {
+			aByte = in[len(in)-1-i]
+		}
+		bitOffset := uint(offset + 8*i)
+		byteWidth := uint(8)
+		if room-8*uint(i) < 8 {
+			byteWidth = room - 8*uint(i)
+		}
+		current, err := getBits(out, bitOffset, byteWidth, bigEndian)
+		if err != nil {
+			return err
+		}
+		if err := setBits(out, bitOffset, byteWidth, current|uint64(aByte)&(1<<byteWidth-1), bigEndian); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (littleEndian) CheckedUint8ShiftedBytes(mask uint64, offset int, b []byte) (uint8, error) {
+	v, err := shiftedGet[uint8](LittleEndian, "LittleEndian.CheckedUint8ShiftedBytes", offset, b, 8, true)
+	return v & uint8(mask), err
+}
+
+func (littleEndian) CheckedPutUint8ShiftedBytes(offset int, b []byte, v uint8) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint8ShiftedBytes", offset, b, 8, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint8ShiftedBytes(offset int, b []byte, v uint8) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint8ShiftedBytes", offset, b, 8, v, true, true)
+}
+
+func (littleEndian) CheckedUint16ShiftedBytes(mask uint64, offset int, b []byte) (uint16, error) {
+	v, err := shiftedGet[uint16](LittleEndian, "LittleEndian.CheckedUint16ShiftedBytes", offset, b, 16, true)
+	return v & uint16(mask), err
+}
+
+func (littleEndian) CheckedPutUint16ShiftedBytes(offset int, b []byte, v uint16) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint16ShiftedBytes", offset, b, 16, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint16ShiftedBytes(offset int, b []byte, v uint16) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint16ShiftedBytes", offset, b, 16, v, true, true)
+}
+
+func (littleEndian) CheckedUint24ShiftedBytes(mask uint64, offset int, b []byte) (uint32, error) {
+	v, err := shiftedGet[uint32](LittleEndian, "LittleEndian.CheckedUint24ShiftedBytes", offset, b, 24, true)
+	return v & uint32(mask), err
+}
+
+func (littleEndian) CheckedPutUint24ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint24ShiftedBytes", offset, b, 24, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint24ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint24ShiftedBytes", offset, b, 24, v, true, true)
+}
+
+func (littleEndian) CheckedUint32ShiftedBytes(mask uint64, offset int, b []byte) (uint32, error) {
+	v, err := shiftedGet[uint32](LittleEndian, "LittleEndian.CheckedUint32ShiftedBytes", offset, b, 32, true)
+	return v & uint32(mask), err
+}
+
+func (littleEndian) CheckedPutUint32ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint32ShiftedBytes", offset, b, 32, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint32ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint32ShiftedBytes", offset, b, 32, v, true, true)
+}
+
+func (littleEndian) CheckedUint40ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](LittleEndian, "LittleEndian.CheckedUint40ShiftedBytes", offset, b, 40, true)
+	return v & mask, err
+}
+
+func (littleEndian) CheckedPutUint40ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint40ShiftedBytes", offset, b, 40, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint40ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint40ShiftedBytes", offset, b, 40, v, true, true)
+}
+
+func (littleEndian) CheckedUint48ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](LittleEndian, "LittleEndian.CheckedUint48ShiftedBytes", offset, b, 48, true)
+	return v & mask, err
+}
+
+func (littleEndian) CheckedPutUint48ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint48ShiftedBytes", offset, b, 48, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint48ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint48ShiftedBytes", offset, b, 48, v, true, true)
+}
+
+func (littleEndian) CheckedUint56ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](LittleEndian, "LittleEndian.CheckedUint56ShiftedBytes", offset, b, 56, true)
+	return v & mask, err
+}
+
+func (littleEndian) CheckedPutUint56ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint56ShiftedBytes", offset, b, 56, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint56ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint56ShiftedBytes", offset, b, 56, v, true, true)
+}
+
+func (littleEndian) CheckedUint64ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](LittleEndian, "LittleEndian.CheckedUint64ShiftedBytes", offset, b, 64, true)
+	return v & mask, err
+}
+
+func (littleEndian) CheckedPutUint64ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedPutUint64ShiftedBytes", offset, b, 64, v, false, true)
+}
+
+func (littleEndian) CheckedClearPutUint64ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(LittleEndian, "LittleEndian.CheckedClearPutUint64ShiftedBytes", offset, b, 64, v, true, true)
+}
+
+func (littleEndian) CheckedPutBytesSliceShiftedBytes(offset int, out, in []byte) error {
+	return checkedBytesSlicePut("LittleEndian.CheckedPutBytesSliceShiftedBytes", offset, out, in, false)
+}
+
+func (littleEndian) CheckedBytesSliceShiftedBytes(mask []byte, offset int, b []byte) ([]byte, error) {
+	return checkedBytesSliceGet("LittleEndian.CheckedBytesSliceShiftedBytes", mask, offset, b, false)
+}
+
+func (bigEndian) CheckedUint8ShiftedBytes(mask uint64, offset int, b []byte) (uint8, error) {
+	v, err := shiftedGet[uint8](BigEndian, "BigEndian.CheckedUint8ShiftedBytes", offset, b, 8, true)
+	return v & uint8(mask), err
+}
+
+func (bigEndian) CheckedPutUint8ShiftedBytes(offset int, b []byte, v uint8) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint8ShiftedBytes", offset, b, 8, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint8ShiftedBytes(offset int, b []byte, v uint8) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint8ShiftedBytes", offset, b, 8, v, true, true)
+}
+
+func (bigEndian) CheckedUint16ShiftedBytes(mask uint64, offset int, b []byte) (uint16, error) {
+	v, err := shiftedGet[uint16](BigEndian, "BigEndian.CheckedUint16ShiftedBytes", offset, b, 16, true)
+	return v & uint16(mask), err
+}
+
+func (bigEndian) CheckedPutUint16ShiftedBytes(offset int, b []byte, v uint16) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint16ShiftedBytes", offset, b, 16, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint16ShiftedBytes(offset int, b []byte, v uint16) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint16ShiftedBytes", offset, b, 16, v, true, true)
+}
+
+func (bigEndian) CheckedUint24ShiftedBytes(mask uint64, offset int, b []byte) (uint32, error) {
+	v, err := shiftedGet[uint32](BigEndian, "BigEndian.CheckedUint24ShiftedBytes", offset, b, 24, true)
+	return v & uint32(mask), err
+}
+
+func (bigEndian) CheckedPutUint24ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint24ShiftedBytes", offset, b, 24, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint24ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint24ShiftedBytes", offset, b, 24, v, true, true)
+}
+
+func (bigEndian) CheckedUint32ShiftedBytes(mask uint64, offset int, b []byte) (uint32, error) {
+	v, err := shiftedGet[uint32](BigEndian, "BigEndian.CheckedUint32ShiftedBytes", offset, b, 32, true)
+	return v & uint32(mask), err
+}
+
+func (bigEndian) CheckedPutUint32ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint32ShiftedBytes", offset, b, 32, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint32ShiftedBytes(offset int, b []byte, v uint32) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint32ShiftedBytes", offset, b, 32, v, true, true)
+}
+
+func (bigEndian) CheckedUint40ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](BigEndian, "BigEndian.CheckedUint40ShiftedBytes", offset, b, 40, true)
+	return v & mask, err
+}
+
+func (bigEndian) CheckedPutUint40ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint40ShiftedBytes", offset, b, 40, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint40ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint40ShiftedBytes", offset, b, 40, v, true, true)
+}
+
+func (bigEndian) CheckedUint48ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](BigEndian, "BigEndian.CheckedUint48ShiftedBytes", offset, b, 48, true)
+	return v & mask, err
+}
+
+func (bigEndian) CheckedPutUint48ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint48ShiftedBytes", offset, b, 48, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint48ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint48ShiftedBytes", offset, b, 48, v, true, true)
+}
+
+func (bigEndian) CheckedUint56ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](BigEndian, "BigEndian.CheckedUint56ShiftedBytes", offset, b, 56, true)
+	return v & mask, err
+}
+
+func (bigEndian) CheckedPutUint56ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint56ShiftedBytes", offset, b, 56, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint56ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint56ShiftedBytes", offset, b, 56, v, true, true)
+}
+
+func (bigEndian) CheckedUint64ShiftedBytes(mask uint64, offset int, b []byte) (uint64, error) {
+	v, err := shiftedGet[uint64](BigEndian, "BigEndian.CheckedUint64ShiftedBytes", offset, b, 64, true)
+	return v & mask, err
+}
+
+func (bigEndian) CheckedPutUint64ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedPutUint64ShiftedBytes", offset, b, 64, v, false, true)
+}
+
+func (bigEndian) CheckedClearPutUint64ShiftedBytes(offset int, b []byte, v uint64) error {
+	return shiftedPut(BigEndian, "BigEndian.CheckedClearPutUint64ShiftedBytes", offset, b, 64, v, true, true)
+}
+
+func (bigEndian) CheckedPutBytesSliceShiftedBytes(offset int, out, in []byte) error {
+	return checkedBytesSlicePut("BigEndian.CheckedPutBytesSliceShiftedBytes", offset, out, in, true)
+}
+
+func (bigEndian) CheckedBytesSliceShiftedBytes(mask []byte, offset int, b []byte) ([]byte, error) {
+	return checkedBytesSliceGet("BigEndian.CheckedBytesSliceShiftedBytes", mask, offset, b, true)
+}
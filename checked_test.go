@@ -0,0 +1,131 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestCheckedShiftedBytes(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		randOffset := rand.Intn(8)
+		randUint := uint32(rand.Int63n(0xFFFFFF))
+
+		// Same results as the panicking accessors on valid input
+		destSlice := make([]byte, 4)
+		checkedSlice := make([]byte, 4)
+		bitwisebytes.LittleEndian.PutUint24ShiftedBytes(randOffset, destSlice, randUint)
+		if err := bitwisebytes.LittleEndian.CheckedPutUint24ShiftedBytes(randOffset, checkedSlice, randUint); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(destSlice, checkedSlice) {
+			t.Fatalf("mistmatch: %X != %X", destSlice, checkedSlice)
+		}
+		if r, err := bitwisebytes.LittleEndian.CheckedUint24ShiftedBytes(0xFFFFFF, randOffset, checkedSlice); err != nil || r != randUint {
+			t.Fatalf("mistmatch: 0x%X != 0x%X %v", r, randUint, err)
+		}
+
+		destSlice = make([]byte, 9)
+		checkedSlice = make([]byte, 9)
+		randUint64 := rand.Uint64()
+		bitwisebytes.BigEndian.PutUint64ShiftedBytes(randOffset, destSlice, randUint64)
+		if err := bitwisebytes.BigEndian.CheckedPutUint64ShiftedBytes(randOffset, checkedSlice, randUint64); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(destSlice, checkedSlice) {
+			t.Fatalf("mistmatch: %X != %X", destSlice, checkedSlice)
+		}
+		if err := bitwisebytes.BigEndian.CheckedClearPutUint64ShiftedBytes(randOffset, checkedSlice, ^randUint64); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r, err := bitwisebytes.BigEndian.CheckedUint64ShiftedBytes(^uint64(0), randOffset, checkedSlice); err != nil || r != ^randUint64 {
+			t.Fatalf("mistmatch: 0x%X != 0x%X %v", r, ^randUint64, err)
+		}
+
+		inSlice := make([]byte, rand.Intn(30)+1)
+		rand.Read(inSlice)
+		inSlice[len(inSlice)-1] &= 0x7F >> uint(randOffset)
+		maskSlice := bytes.Repeat([]byte{0xFF}, len(inSlice))
+		destSlice = make([]byte, len(inSlice)+1)
+		checkedSlice = make([]byte, len(inSlice)+1)
+		bitwisebytes.LittleEndian.PutBytesSliceShiftedBytes(randOffset, destSlice, inSlice)
+		if err := bitwisebytes.LittleEndian.CheckedPutBytesSliceShiftedBytes(randOffset, checkedSlice, inSlice); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(destSlice, checkedSlice) {
+			t.Fatalf("mistmatch: %X != %X", destSlice, checkedSlice)
+		}
+		r, err := bitwisebytes.LittleEndian.CheckedBytesSliceShiftedBytes(maskSlice, randOffset, checkedSlice)
+		if err != nil || !bytes.Equal(r, inSlice) {
+			t.Fatalf("mistmatch: %X != %X %v", r, inSlice, err)
+		}
+
+		for j := range inSlice {
+			inSlice[j] = byte(rand.Intn(256))
+		}
+		inSlice[0] &= 0x7F >> uint(randOffset)
+		checkedSlice = make([]byte, len(inSlice)+1)
+		if err := bitwisebytes.BigEndian.CheckedPutBytesSliceShiftedBytes(randOffset, checkedSlice, inSlice); err != nil {
+			t.Fatal(err.Error())
+		}
+		r, err = bitwisebytes.BigEndian.CheckedBytesSliceShiftedBytes(maskSlice, randOffset, checkedSlice)
+		if err != nil || !bytes.Equal(r, inSlice) {
+			t.Fatalf("mistmatch: %X != %X %v", r, inSlice, err)
+		}
+	}
+}
+
+func TestCheckedErrors(t *testing.T) {
+	_, getErr := bitwisebytes.LittleEndian.CheckedUint32ShiftedBytes(0xFFFFFFFF, 3, make([]byte, 4))
+	_, maskErr := bitwisebytes.CheckedMakeMask(2, 12, 5)
+	_, wrapErr := bitwisebytes.CheckedMakeMask(2, 8, ^uint(0)-3)
+
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"short get", getErr, bitwisebytes.ErrBufferTooShort},
+		{"mask", maskErr, bitwisebytes.ErrBufferTooShort},
+		{"wrapping mask", wrapErr, bitwisebytes.ErrBufferTooShort},
+		{"negative offset", bitwisebytes.BigEndian.CheckedPutUint16ShiftedBytes(-1, make([]byte, 3), 1), bitwisebytes.ErrOffsetOutOfRange},
+		{"offset", bitwisebytes.LittleEndian.CheckedPutUint16ShiftedBytes(8, make([]byte, 3), 1), bitwisebytes.ErrOffsetOutOfRange},
+		{"short put", bitwisebytes.BigEndian.CheckedPutUint32ShiftedBytes(1, make([]byte, 3), 1), bitwisebytes.ErrBufferTooShort},
+		{"long put", bitwisebytes.LittleEndian.CheckedPutUint8ShiftedBytes(1, make([]byte, 3), 1), bitwisebytes.ErrBufferTooLong},
+		{"overflow", bitwisebytes.LittleEndian.CheckedPutUint16ShiftedBytes(4, make([]byte, 2), 0xF000), bitwisebytes.ErrValueOverflow},
+		{"bytes overflow", bitwisebytes.BigEndian.CheckedPutBytesSliceShiftedBytes(1, make([]byte, 2), []byte{0x80, 0}), bitwisebytes.ErrValueOverflow},
+		{"width", bitwisebytes.LittleEndian.SetBits(make([]byte, 16), 0, 65, 0), bitwisebytes.ErrWidthOutOfRange},
+		{"set overflow", bitwisebytes.LittleEndian.SetBits(make([]byte, 2), 3, 4, 0x10), bitwisebytes.ErrValueOverflow},
+	}
+
+	for _, c := range cases {
+		if !errors.Is(c.err, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, c.err)
+		}
+	}
+
+	var fieldErr *bitwisebytes.FieldError
+	err := bitwisebytes.LittleEndian.CheckedPutUint16ShiftedBytes(4, make([]byte, 2), 0xF000)
+	if !errors.As(err, &fieldErr) || fieldErr.Offset != 4 || fieldErr.Width != 16 {
+		t.Errorf("expected a FieldError at offset 4 width 16, got %v", err)
+	}
+	if fieldErr.Op != "LittleEndian.CheckedPutUint16ShiftedBytes" {
+		t.Errorf("expected the error to name the Checked method, got %s", fieldErr.Op)
+	}
+
+	_, err = bitwisebytes.LittleEndian.CheckedUint16ShiftedBytes(0xFFFF, -3, make([]byte, 3))
+	if !errors.As(err, &fieldErr) || fieldErr.Offset != 0 || !strings.Contains(err.Error(), "negative offset -3") {
+		t.Errorf("expected a negative offset error, got %v", err)
+	}
+
+	// Nothing is written on error
+	destSlice := []byte{0xAA, 0x55}
+	bitwisebytes.LittleEndian.CheckedPutUint16ShiftedBytes(4, destSlice, 0xF000)
+	if !bytes.Equal(destSlice, []byte{0xAA, 0x55}) {
+		t.Errorf("buffer modified on error: %X", destSlice)
+	}
+}
@@ -0,0 +1,83 @@
+package bitwisebytes
+
+import "unsafe"
+
+// readChunk returns the width bits, at most 64, found at bitOffset of the
+// little endian slice of bytes b
+func readChunk(b []byte, bitOffset, width uint) uint64 {
+	start := int(bitOffset / 8)
+	shift := bitOffset % 8
+	word := loadBytes64(b, start) >> shift
+	if shift+width > 64 {
+		word |= uint64(b[start+8]) << (64 - shift)
+	}
+	if width < 64 {
+		word &= 1<<width - 1
+	}
+	return word
+}
+
+// writeChunk overwrites the width bits found at bitOffset of the little
+// endian slice of bytes b with v, the field must not cross the 8 bytes
+// starting at the byte holding bitOffset
+func writeChunk(b []byte, bitOffset, width uint, v uint64) {
+	start := int(bitOffset / 8)
+	shift := bitOffset % 8
+	if shift == 0 && width == 64 {
+		storeBytes64(b, start, v)
+		return
+	}
+	mask := (uint64(1)<<width - 1) << shift
+	storeBytes64(b, start, loadBytes64(b, start)&^mask|v<<shift&mask)
+}
+
+// bitAddress returns the absolute bit address of bit bitOffset of b, used to
+// pick a copy direction that is safe when src and dst overlap
+func bitAddress(b []byte, bitOffset uint) uint64 {
+	return uint64(uintptr(unsafe.Pointer(unsafe.SliceData(b))))*8 + uint64(bitOffset)
+}
+
+// CopyBits copies the n bits found at srcOff of src to dstOff of dst, both
+// little endian slices of bytes. Like memmove it gives the expected result
+// when the two regions overlap. The bits of dst outside of the region are
+// left untouched. Nothing is copied when either region does not fit in its
+// buffer.
+func CopyBits(dst []byte, dstOff uint, src []byte, srcOff, n uint) (err error) {
+	if srcOff > uint(len(src))*8 || n > uint(len(src))*8-srcOff {
+		return fieldError("CopyBits", srcOff, n, ErrBufferTooShort)
+	}
+	if dstOff > uint(len(dst))*8 || n > uint(len(dst))*8-dstOff {
+		return fieldError("CopyBits", dstOff, n, ErrBufferTooShort)
+	}
+
+	// ------------------------------------------------------------
+	// Copy in chunks of up to 64 bits, every chunk but the first
+	// one starts on a dst byte boundary so it is stored as a whole
+	// word. Every chunk is read before it is written, going down
+	// when dst is above src keeps unread src bits from being
+	// overwritten.
+	// ------------------------------------------------------------
+	if bitAddress(dst, dstOff) <= bitAddress(src, srcOff) {
+		for copied := uint(0); copied < n; {
+			chunk := 64 - (dstOff+copied)%8
+			if chunk > n-copied {
+				chunk = n - copied
+			}
+			writeChunk(dst, dstOff+copied, chunk, readChunk(src, srcOff+copied, chunk))
+			copied += chunk
+		}
+		return err
+	}
+	for left := n; left > 0; {
+		chunk := uint(64)
+		if end := (dstOff + left) % 8; end != 0 {
+			chunk = 56 + end
+		}
+		if chunk > left {
+			chunk = left
+		}
+		left -= chunk
+		writeChunk(dst, dstOff+left, chunk, readChunk(src, srcOff+left, chunk))
+	}
+	return err
+}
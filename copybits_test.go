@@ -0,0 +1,83 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+// copyBitsReference copies n bits one at a time through a temporary buffer
+func copyBitsReference(dst []byte, dstOff uint, src []byte, srcOff, n uint) {
+	tmp := make([]bool, n)
+	for i := uint(0); i < n; i++ {
+		tmp[i] = src[(srcOff+i)/8]>>((srcOff+i)%8)&1 == 1
+	}
+	for i, isSet := range tmp {
+		bitNum := dstOff + uint(i)
+		dst[bitNum/8] &^= 1 << (bitNum % 8)
+		if isSet {
+			dst[bitNum/8] |= 1 << (bitNum % 8)
+		}
+	}
+}
+
+func TestCopyBits(t *testing.T) {
+	for i := 0; i < testLooops*10; i++ {
+		src := make([]byte, rand.Intn(40)+1)
+		dst := make([]byte, rand.Intn(40)+1)
+		rand.Read(src)
+		rand.Read(dst)
+		maxBits := len(src) * 8
+		if len(dst) < len(src) {
+			maxBits = len(dst) * 8
+		}
+		n := uint(rand.Intn(maxBits + 1))
+		srcOff := uint(rand.Intn(len(src)*8 - int(n) + 1))
+		dstOff := uint(rand.Intn(len(dst)*8 - int(n) + 1))
+
+		expected := append([]byte(nil), dst...)
+		copyBitsReference(expected, dstOff, src, srcOff, n)
+		if err := bitwisebytes.CopyBits(dst, dstOff, src, srcOff, n); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(dst, expected) {
+			t.Fatalf("n:%d srcOff:%d dstOff:%d mistmatch:\n%X\n%X", n, srcOff, dstOff, dst, expected)
+		}
+
+		// Overlapping regions of the same buffer, in both directions
+		overlapped := append([]byte(nil), src...)
+		n = uint(rand.Intn(len(src)*8 + 1))
+		srcOff = uint(rand.Intn(len(src)*8 - int(n) + 1))
+		dstOff = uint(rand.Intn(len(src)*8 - int(n) + 1))
+		expected = append([]byte(nil), src...)
+		copyBitsReference(expected, dstOff, src, srcOff, n)
+		if err := bitwisebytes.CopyBits(overlapped, dstOff, overlapped, srcOff, n); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !bytes.Equal(overlapped, expected) {
+			t.Fatalf("overlap n:%d srcOff:%d dstOff:%d mistmatch:\n%X\n%X", n, srcOff, dstOff, overlapped, expected)
+		}
+	}
+
+	dst := []byte{0xAA, 0x55}
+	if err := bitwisebytes.CopyBits(dst, 4, make([]byte, 2), 0, 13); !errors.Is(err, bitwisebytes.ErrBufferTooShort) || !bytes.Equal(dst, []byte{0xAA, 0x55}) {
+		t.Errorf("expected ErrBufferTooShort and an untouched buffer, got %v %X", err, dst)
+	}
+	// srcOff+n wraps around, it must not pass the range check
+	if err := bitwisebytes.CopyBits(dst, 0, make([]byte, 2), ^uint(0)-3, 8); !errors.Is(err, bitwisebytes.ErrBufferTooShort) || !bytes.Equal(dst, []byte{0xAA, 0x55}) {
+		t.Errorf("expected ErrBufferTooShort and an untouched buffer, got %v %X", err, dst)
+	}
+}
+
+func BenchmarkCopyBits(b *testing.B) {
+	src := make([]byte, 4096)
+	dst := make([]byte, 4096)
+	rand.Read(src)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bitwisebytes.CopyBits(dst, 3, src, 5, 4000*8)
+	}
+}
@@ -0,0 +1,51 @@
+package bitwisebytes
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrOffsetOutOfRange is returned when a field offset is not valid
+	ErrOffsetOutOfRange = errors.New("offset out of range")
+	// ErrWidthOutOfRange is returned when a field width is 0 or too wide
+	ErrWidthOutOfRange = errors.New("width out of range")
+	// ErrBufferTooShort is returned when a field does not fit in the buffer
+	ErrBufferTooShort = errors.New("buffer too short")
+	// ErrBufferTooLong is returned when a buffer has more bytes than the
+	// accessor can address
+	ErrBufferTooLong = errors.New("buffer too long")
+	// ErrValueOverflow is returned when a value does not fit in its field
+	ErrValueOverflow = errors.New("value overflows field")
+)
+
+// FieldError records the field an operation failed on. Err is one of the
+// Err* values above, test for them with errors.Is.
+type FieldError struct {
+	Op     string
+	Offset uint
+	Width  uint
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: field offset:%d width:%d: %s", e.Op, e.Offset, e.Width, e.Err.Error())
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func fieldError(op string, offset, width uint, err error) error {
+	return &FieldError{Op: op, Offset: offset, Width: width, Err: err}
+}
+
+// shiftedOffsetError returns the error of a ShiftedBytes offset outside of
+// 0..7. FieldError offsets are unsigned, a negative one is reported as 0
+// with its value in the message.
+func shiftedOffsetError(op string, offset int, width uint) error {
+	if offset < 0 {
+		return fieldError(op, 0, width, fmt.Errorf("%w: negative offset %d", ErrOffsetOutOfRange, offset))
+	}
+	return fieldError(op, uint(offset), width, ErrOffsetOutOfRange)
+}
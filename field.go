@@ -0,0 +1,199 @@
+package bitwisebytes
+
+import "math/bits"
+
+// Unsigned is the set of types a Field can hold
+type Unsigned interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Field is the Width bits unsigned field found at bit Offset of a buffer
+// laid out in Order. Offset counts from the least significant bit of the
+// buffer like the GetBits offsets, Width goes from 1 to the number of bits
+// of T. A nil Order reads as LittleEndian.
+type Field[T Unsigned] struct {
+	Order  ByteOrder
+	Offset uint
+	Width  uint
+}
+
+// check validates f against a len(b) bytes buffer
+func (f Field[T]) check(op string, b []byte) error {
+	if f.Width > uint(bits.Len64(uint64(^T(0)))) {
+		return fieldError(op, f.Offset, f.Width, ErrWidthOutOfRange)
+	}
+	return checkField(op, len(b), f.Offset, f.Width)
+}
+
+// fits returns ErrValueOverflow, as a *FieldError, when v is wider than f
+func (f Field[T]) fits(op string, v T) error {
+	if f.Width < 64 && uint64(v)>>f.Width != 0 {
+		return fieldError(op, f.Offset, f.Width, ErrValueOverflow)
+	}
+	return nil
+}
+
+// load returns the value of f in b, which must hold f
+func (f Field[T]) load(b []byte) T {
+	return T(loadField(b, f.Offset, f.Width, f.Order == BigEndian))
+}
+
+// store overwrites, or ORs when or is set, f in b with v, which must fit in f
+func (f Field[T]) store(b []byte, v T, or bool) {
+	bigEndian := f.Order == BigEndian
+	x := uint64(v)
+	if or {
+		x |= loadField(b, f.Offset, f.Width, bigEndian)
+	}
+	storeField(b, f.Offset, f.Width, x, bigEndian)
+}
+
+// Get returns the value of f in b
+func (f Field[T]) Get(b []byte) (T, error) {
+	if err := f.check("Field.Get", b); err != nil {
+		return 0, err
+	}
+	return f.load(b), nil
+}
+
+// Put overwrites f in b with v, no bit outside of f is modified
+func (f Field[T]) Put(b []byte, v T) error {
+	if err := f.check("Field.Put", b); err != nil {
+		return err
+	}
+	if err := f.fits("Field.Put", v); err != nil {
+		return err
+	}
+	f.store(b, v, false)
+	return nil
+}
+
+// OrPut ORs v into f in b, no bit outside of f is modified
+func (f Field[T]) OrPut(b []byte, v T) error {
+	if err := f.check("Field.OrPut", b); err != nil {
+		return err
+	}
+	if err := f.fits("Field.OrPut", v); err != nil {
+		return err
+	}
+	f.store(b, v, true)
+	return nil
+}
+
+// truncate drops the bits of v that do not fit in width bits
+func truncate[T Unsigned](v T, width uint) T {
+	if width >= 64 {
+		return v
+	}
+	return v & T(uint64(1)<<width-1)
+}
+
+// ------------------------------------------------------------------
+//             The ShiftedBytes accessors of ByteOrder over Field
+// ------------------------------------------------------------------
+// The ShiftedBytes accessors and their Checked versions share shiftedGet
+// and shiftedPut. The strict, Checked, ones return a *FieldError when the
+// field does not fit in b, the others keep their historical contract:
+// values are truncated to the room b has for them and a bad offset or size
+// panics with a *FieldError.
+
+func checkShiftedOffset(op string, offset int, width uint) {
+	if offset < 0 || offset > 7 {
+		panic(shiftedOffsetError(op, offset, width))
+	}
+}
+
+// shiftedGet reads the width bits field at offset of the first width/8+1
+// bytes of b. Unless strict a 64 bits field may also be read from just 8
+// bytes, its top offset bits reading as zero.
+func shiftedGet[T Unsigned](order ByteOrder, op string, offset int, b []byte, width uint, strict bool) (T, error) {
+	if offset < 0 || offset > 7 {
+		return 0, shiftedOffsetError(op, offset, width)
+	}
+	size := width/8 + 1
+	if !strict && width == 64 && len(b) == 8 {
+		size = 8
+	}
+	if uint(len(b)) > size {
+		b = b[:size]
+	}
+	if uint(len(b)) < size && (strict || uint(len(b))*8 < uint(offset)+width) {
+		return 0, fieldError(op, uint(offset), width, ErrBufferTooShort)
+	}
+	if room := uint(len(b))*8 - uint(offset); room < width {
+		width = room
+	}
+	return Field[T]{Order: order, Offset: uint(offset), Width: width}.load(b), nil
+}
+
+// shiftedPut ORs, or overwrites when clear is set, v into the width bits
+// field at offset of b, which must be width/8 or width/8+1 bytes long. When
+// b has no room for the top bits of v they are dropped, or rejected with
+// ErrValueOverflow when strict.
+func shiftedPut[T Unsigned](order ByteOrder, op string, offset int, b []byte, width uint, v T, clear, strict bool) error {
+	if offset < 0 || offset > 7 {
+		return shiftedOffsetError(op, offset, width)
+	}
+	switch {
+	case uint(len(b)) < width/8:
+		return fieldError(op, uint(offset), width, ErrBufferTooShort)
+	case uint(len(b)) > width/8+1:
+		return fieldError(op, uint(offset), width, ErrBufferTooLong)
+	}
+	room := width
+	if bufferRoom := uint(len(b))*8 - uint(offset); bufferRoom < width {
+		room = bufferRoom
+	}
+	if strict && truncate(v, room) != v {
+		return fieldError(op, uint(offset), width, ErrValueOverflow)
+	}
+	Field[T]{Order: order, Offset: uint(offset), Width: room}.store(b, truncate(v, room), !clear)
+	return nil
+}
+
+// shiftedUint backs the UintNShiftedBytes getters. In the common case the
+// field fits in the first width/8+1 bytes of b, which are loaded as a single
+// word.
+func shiftedUint(order ByteOrder, op string, offset int, b []byte, width uint) uint64 {
+	if uint(offset) > 7 || width == 64 || uint(len(b)) <= width/8 {
+		v, err := shiftedGet[uint64](order, op, offset, b, width, false)
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}
+	b = b[:width/8+1]
+	var word uint64
+	if order == BigEndian {
+		word = loadReversed64(b, 0)
+	} else {
+		word = loadBytes64(b, 0)
+	}
+	return word >> uint(offset) & (1<<width - 1)
+}
+
+// putShiftedUint backs the PutUintNShiftedBytes setters, storing the field
+// as a single word when b is exactly width/8+1 bytes long.
+func putShiftedUint(order ByteOrder, op string, offset int, b []byte, width uint, v uint64, clear bool) {
+	if uint(offset) > 7 || width == 64 || uint(len(b)) != width/8+1 {
+		if err := shiftedPut(order, op, offset, b, width, v, clear, false); err != nil {
+			panic(err)
+		}
+		return
+	}
+	mask := uint64(1)<<width - 1
+	v = v & mask << uint(offset)
+	if order == BigEndian {
+		word := loadReversed64(b, 0)
+		if clear {
+			word &^= mask << uint(offset)
+		}
+		storeReversed64(b, 0, word|v)
+		return
+	}
+	word := loadBytes64(b, 0)
+	if clear {
+		word &^= mask << uint(offset)
+	}
+	storeBytes64(b, 0, word|v)
+}
@@ -0,0 +1,97 @@
+package bitwisebytes_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+type register uint16
+
+func TestField(t *testing.T) {
+	for _, order := range []fieldOrder{bitwisebytes.LittleEndian, bitwisebytes.BigEndian} {
+		for i := 0; i < testLooops; i++ {
+			bytesSlice := make([]byte, 10)
+			rand.Read(bytesSlice)
+			width := uint(rand.Intn(64) + 1)
+			f := bitwisebytes.Field[uint64]{Order: order, Offset: uint(rand.Intn(80 - int(width) + 1)), Width: width}
+			v := rand.Uint64()
+			if width < 64 {
+				v &= 1<<width - 1
+			}
+
+			if err := f.Put(bytesSlice, v); err != nil {
+				t.Fatal(err.Error())
+			}
+			if r, err := f.Get(bytesSlice); err != nil || r != v {
+				t.Fatalf("width:%d offset:%d mistmatch: 0x%X != 0x%X %v", f.Width, f.Offset, r, v, err)
+			}
+			if r, _ := order.GetBits(bytesSlice, f.Offset, f.Width); r != v {
+				t.Fatalf("width:%d offset:%d GetBits mistmatch: 0x%X != 0x%X", f.Width, f.Offset, r, v)
+			}
+
+			extra := rand.Uint64() & v
+			if err := f.Put(bytesSlice, v&^extra); err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := f.OrPut(bytesSlice, extra); err != nil {
+				t.Fatal(err.Error())
+			}
+			if r, _ := f.Get(bytesSlice); r != v {
+				t.Fatalf("width:%d offset:%d OrPut mistmatch: 0x%X != 0x%X", f.Width, f.Offset, r, v)
+			}
+		}
+	}
+
+	// Named types work, and the field can not be wider than its type
+	f := bitwisebytes.Field[register]{Order: bitwisebytes.BigEndian, Offset: 4, Width: 12}
+	bytesSlice := make([]byte, 2)
+	if err := f.Put(bytesSlice, 0xABC); err != nil || bytesSlice[0] != 0xAB || bytesSlice[1] != 0xC0 {
+		t.Errorf("unexpected field bytes %X %v", bytesSlice, err)
+	}
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"too wide for its type", bitwisebytes.Field[register]{Width: 17}.Put(make([]byte, 4), 1), bitwisebytes.ErrWidthOutOfRange},
+		{"zero width", bitwisebytes.Field[uint8]{}.Put(make([]byte, 4), 0), bitwisebytes.ErrWidthOutOfRange},
+		{"overflow", f.OrPut(bytesSlice, 0x1000), bitwisebytes.ErrValueOverflow},
+		{"short", bitwisebytes.Field[uint32]{Offset: 9, Width: 8}.Put(make([]byte, 2), 1), bitwisebytes.ErrBufferTooShort},
+	}
+	for _, c := range cases {
+		if !errors.Is(c.err, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, c.err)
+		}
+	}
+}
+
+func TestShiftedBytesPanics(t *testing.T) {
+	cases := []struct {
+		name     string
+		call     func()
+		expected error
+	}{
+		{"offset", func() { bitwisebytes.LittleEndian.Uint16ShiftedBytes(0xFFFF, 8, make([]byte, 3)) }, bitwisebytes.ErrOffsetOutOfRange},
+		{"negative offset", func() { bitwisebytes.BigEndian.PutUint16ShiftedBytes(-1, make([]byte, 3), 1) }, bitwisebytes.ErrOffsetOutOfRange},
+		{"long", func() { bitwisebytes.BigEndian.PutUint24ShiftedBytes(1, make([]byte, 5), 1) }, bitwisebytes.ErrBufferTooLong},
+		{"short", func() { bitwisebytes.LittleEndian.ClearPutUint32ShiftedBytes(1, make([]byte, 3), 1) }, bitwisebytes.ErrBufferTooShort},
+		{"short get", func() { bitwisebytes.BigEndian.Uint32ShiftedBytes(-1, 1, make([]byte, 3)) }, bitwisebytes.ErrBufferTooShort},
+		{"bytes offset", func() { bitwisebytes.LittleEndian.BytesSliceShiftedBytes([]byte{0xFF}, 8, make([]byte, 2)) }, bitwisebytes.ErrOffsetOutOfRange},
+		{"put bytes offset", func() { bitwisebytes.BigEndian.PutBytesSliceShiftedBytes(-1, make([]byte, 2), []byte{1}) }, bitwisebytes.ErrOffsetOutOfRange},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				var fieldErr *bitwisebytes.FieldError
+				if !errors.Is(err, c.expected) || !errors.As(err, &fieldErr) || fieldErr.Op == "" {
+					t.Errorf("%s: expected a %v *FieldError panic, got %v", c.name, c.expected, err)
+				}
+			}()
+			c.call()
+		}()
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/lagarciag/bitwisebytes
+
+go 1.23
@@ -0,0 +1,291 @@
+package bitwisebytes
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Marshal and Unmarshal map the fields of a struct onto a bit packed buffer.
+// Each field is described by a bits tag:
+//
+//	Mode  uint8 `bits:"offset=3,width=5,order=le"`
+//
+// offset is the position of the field LSB in bits (as in GetBits) and is
+// relative to the enclosing struct, width defaults to the size of the Go
+// type and order, le or be, defaults to the one of the enclosing struct
+// (little endian at the top). Offsets always count from the LSB of b[0],
+// order only sets how the bytes spanned by the field are laid out, so a
+// be field at offset=56,width=24 is stored big endian in b[7:10].
+// Supported field types are bool (1 bit), signed and unsigned integers,
+// nested structs and fixed size arrays, whose width is the width of each
+// element. Untagged fields are ignored.
+
+// bitField is a leaf of a bits tagged struct
+type bitField struct {
+	path   string
+	value  reflect.Value
+	offset uint
+	width  uint
+	order  ByteOrder
+}
+
+type fieldTag struct {
+	offset   uint
+	width    uint
+	hasWidth bool
+	order    ByteOrder
+}
+
+func parseFieldTag(tag string, order ByteOrder) (ft fieldTag, err error) {
+	ft.order = order
+	hasOffset := false
+	for _, item := range strings.Split(tag, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 {
+			return ft, fmt.Errorf("malformed tag item %q", item)
+		}
+		switch kv[0] {
+		case "offset", "width":
+			n, err := strconv.ParseUint(kv[1], 0, 0)
+			if err != nil {
+				return ft, fmt.Errorf("invalid %s %q", kv[0], kv[1])
+			}
+			if kv[0] == "offset" {
+				ft.offset, hasOffset = uint(n), true
+			} else {
+				ft.width, ft.hasWidth = uint(n), true
+			}
+		case "order":
+			switch kv[1] {
+			case "le":
+				ft.order = LittleEndian
+			case "be":
+				ft.order = BigEndian
+			default:
+				return ft, fmt.Errorf("invalid order %q", kv[1])
+			}
+		default:
+			return ft, fmt.Errorf("unknown tag key %q", kv[0])
+		}
+	}
+	if !hasOffset {
+		return ft, fmt.Errorf("missing offset")
+	}
+	return ft, nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// typeBits returns the number of bits a value of type t spans
+func typeBits(t reflect.Type, width uint, hasWidth bool) (uint, error) {
+	if hasWidth && t.Kind() != reflect.Array {
+		return width, nil
+	}
+	switch t.Kind() {
+	case reflect.Array:
+		elemBits, err := typeBits(t.Elem(), width, hasWidth)
+		return uint(t.Len()) * elemBits, err
+	case reflect.Struct:
+		fields, err := collectFields(reflect.New(t).Elem(), 0, 0, false, LittleEndian, t.Name(), nil)
+		return fieldsBits(fields), err
+	case reflect.Bool:
+		return 1, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(t.Bits()), nil
+	}
+	return 0, fmt.Errorf("unsupported type %s", t)
+}
+
+// collectFields flattens v into the list of its leaf fields
+func collectFields(v reflect.Value, offset, width uint, hasWidth bool, order ByteOrder, path string, fields []bitField) ([]bitField, error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag, ok := sf.Tag.Lookup("bits")
+			if !ok || tag == "-" {
+				continue
+			}
+			fieldPath := joinPath(path, sf.Name)
+			if sf.PkgPath != "" {
+				return fields, fmt.Errorf("%s: unexported field", fieldPath)
+			}
+			ft, err := parseFieldTag(tag, order)
+			if err != nil {
+				return fields, fmt.Errorf("%s: %s", fieldPath, err.Error())
+			}
+			fields, err = collectFields(v.Field(i), offset+ft.offset, ft.width, ft.hasWidth, ft.order, fieldPath, fields)
+			if err != nil {
+				return fields, err
+			}
+		}
+		return fields, nil
+
+	case reflect.Array:
+		stride, err := typeBits(v.Type().Elem(), width, hasWidth)
+		if err != nil {
+			return fields, fmt.Errorf("%s: %s", path, err.Error())
+		}
+		for i := 0; i < v.Len(); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			fields, err = collectFields(v.Index(i), offset+uint(i)*stride, width, hasWidth, order, elemPath, fields)
+			if err != nil {
+				return fields, err
+			}
+		}
+		return fields, nil
+	}
+
+	typeWidth, err := typeBits(v.Type(), 0, false)
+	if err != nil {
+		return fields, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	if !hasWidth {
+		width = typeWidth
+	}
+	if width == 0 || width > typeWidth || (v.Kind() == reflect.Bool && width != 1) {
+		return fields, fmt.Errorf("%s: invalid width %d for %s", path, width, v.Type())
+	}
+	return append(fields, bitField{path: path, value: v, offset: offset, width: width, order: order}), nil
+}
+
+// fieldsBits returns the number of bits needed to hold all the fields
+func fieldsBits(fields []bitField) (bits uint) {
+	for _, f := range fields {
+		if f.offset+f.width > bits {
+			bits = f.offset + f.width
+		}
+	}
+	return bits
+}
+
+// span returns the bytes of b the field spans and the field offset in them
+func (f bitField) span(b []byte) ([]byte, uint) {
+	first := f.offset / 8
+	last := (f.offset + f.width - 1) / 8
+	return b[first : last+1], f.offset - first*8
+}
+
+// checkOverlap verifies that no bit of a size bytes buffer is claimed by
+// more than one field
+func checkOverlap(fields []bitField, size int) error {
+	owners := make([]int, size*8)
+	for i, f := range fields {
+		first := f.offset / 8 * 8
+		spanSize := int((f.offset+f.width-1)/8 - f.offset/8 + 1)
+		for bitNum := f.offset - first; bitNum < f.offset-first+f.width; bitNum++ {
+			physical := first + fieldByte(spanSize, bitNum, f.order == BigEndian)*8 + bitNum%8
+			if owners[physical] != 0 {
+				return fmt.Errorf("%s overlaps %s", f.path, fields[owners[physical]-1].path)
+			}
+			owners[physical] = i + 1
+		}
+	}
+	return nil
+}
+
+func structFields(v reflect.Value) ([]bitField, error) {
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %s", v.Type())
+	}
+	return collectFields(v, 0, 0, false, LittleEndian, v.Type().Name(), nil)
+}
+
+// Marshal packs the bits tagged fields of the struct v into a buffer just
+// wide enough to hold all of them
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr {
+		return nil, fmt.Errorf("expected a struct or a non nil pointer to one, got %T", v)
+	}
+	fields, err := structFields(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int((fieldsBits(fields) + 7) / 8)
+	if err := checkOverlap(fields, size); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, size)
+	for _, f := range fields {
+		var x uint64
+		switch f.value.Kind() {
+		case reflect.Bool:
+			if f.value.Bool() {
+				x = 1
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i := f.value.Int()
+			if f.width < 64 && (i < -1<<(f.width-1) || i >= 1<<(f.width-1)) {
+				return nil, fmt.Errorf("%s: value %d out of range for %d bits: %w", f.path, i, f.width, ErrValueOverflow)
+			}
+			x = uint64(i)
+			if f.width < 64 {
+				x &= 1<<f.width - 1
+			}
+		default:
+			x = f.value.Uint()
+			if f.width < 64 && x>>f.width != 0 {
+				return nil, fmt.Errorf("%s: value %d out of range for %d bits: %w", f.path, x, f.width, ErrValueOverflow)
+			}
+		}
+		span, offset := f.span(b)
+		if err := setBits(span, offset, f.width, x, f.order == BigEndian); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.path, err)
+		}
+	}
+	return b, nil
+}
+
+// Unmarshal unpacks b into the bits tagged fields of the struct pointed by v
+func Unmarshal(b []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("expected a non nil pointer to a struct, got %T", v)
+	}
+	fields, err := structFields(rv.Elem())
+	if err != nil {
+		return err
+	}
+
+	if bits := fieldsBits(fields); uint(len(b))*8 < bits {
+		return fmt.Errorf("buffer of %d bytes too short for %d bits: %w", len(b), bits, ErrBufferTooShort)
+	}
+	if err := checkOverlap(fields, len(b)); err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		span, offset := f.span(b)
+		x, err := getBits(span, offset, f.width, f.order == BigEndian)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+		switch f.value.Kind() {
+		case reflect.Bool:
+			f.value.SetBool(x != 0)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if f.width < 64 && x>>(f.width-1) != 0 {
+				x |= ^uint64(0) << f.width
+			}
+			f.value.SetInt(int64(x))
+		default:
+			f.value.SetUint(x)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package bitwisebytes_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+type testLane struct {
+	Enable bool  `bits:"offset=0"`
+	Gain   int8  `bits:"offset=1,width=5"`
+	Mode   uint8 `bits:"offset=6,width=2"`
+}
+
+type testRegister struct {
+	Valid  bool        `bits:"offset=0"`
+	Kind   uint8       `bits:"offset=1,width=3"`
+	Delta  int16       `bits:"offset=4,width=12"`
+	Lanes  [3]testLane `bits:"offset=16"`
+	Counts [4]uint8    `bits:"offset=40,width=4"`
+	Stamp  uint32      `bits:"offset=56,width=24,order=be"`
+	Notes  string
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		in := testRegister{
+			Valid: rand.Intn(2) == 1,
+			Kind:  uint8(rand.Intn(8)),
+			Delta: int16(rand.Intn(1<<12) - 1<<11),
+			Stamp: uint32(rand.Intn(1 << 24)),
+			Notes: "ignored",
+		}
+		for j := range in.Lanes {
+			in.Lanes[j] = testLane{rand.Intn(2) == 1, int8(rand.Intn(32) - 16), uint8(rand.Intn(4))}
+		}
+		for j := range in.Counts {
+			in.Counts[j] = uint8(rand.Intn(16))
+		}
+
+		b, err := bitwisebytes.Marshal(&in)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(b) != 10 {
+			t.Fatalf("expected 10 bytes, got %d", len(b))
+		}
+
+		if v, _ := bitwisebytes.LittleEndian.GetBits(b, 4, 12); int16(v<<4)>>4 != in.Delta {
+			t.Errorf("Delta not at its offset: 0x%X", v)
+		}
+		if v := bitwisebytes.BigEndian.Uint24(b[7:]); v != in.Stamp {
+			t.Errorf("Stamp not at its offset: 0x%X", v)
+		}
+
+		var out testRegister
+		if err := bitwisebytes.Unmarshal(b, &out); err != nil {
+			t.Fatal(err.Error())
+		}
+		in.Notes = ""
+		if out != in {
+			t.Errorf("mistmatch: %+v != %+v", out, in)
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	type overlap struct {
+		A uint8 `bits:"offset=0,width=4"`
+		B uint8 `bits:"offset=3,width=4"`
+	}
+	type nested struct {
+		Inner [2]testLane `bits:"offset=0"`
+	}
+
+	_, err := bitwisebytes.Marshal(overlap{})
+	if err == nil || !strings.Contains(err.Error(), "overlap.B") {
+		t.Errorf("expected overlap error naming overlap.B, got %v", err)
+	}
+
+	_, err = bitwisebytes.Marshal(nested{Inner: [2]testLane{{}, {Gain: 16}}})
+	if err == nil || !strings.Contains(err.Error(), "nested.Inner[1].Gain") {
+		t.Errorf("expected range error naming nested.Inner[1].Gain, got %v", err)
+	}
+
+	_, err = bitwisebytes.Marshal(testRegister{Kind: 8})
+	if err == nil || !strings.Contains(err.Error(), "testRegister.Kind") {
+		t.Errorf("expected range error naming testRegister.Kind, got %v", err)
+	}
+
+	var out testRegister
+	if err := bitwisebytes.Unmarshal(make([]byte, 4), &out); err == nil {
+		t.Error("short buffer must fail")
+	}
+	if err := bitwisebytes.Unmarshal(make([]byte, 10), out); err == nil {
+		t.Error("non pointer must fail")
+	}
+	if _, err := bitwisebytes.Marshal(nil); err == nil {
+		t.Error("nil must fail")
+	}
+	if _, err := bitwisebytes.Marshal((*testRegister)(nil)); err == nil {
+		t.Error("nil pointer must fail")
+	}
+}
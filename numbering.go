@@ -0,0 +1,86 @@
+package bitwisebytes
+
+// BitNumbering selects how the bit offsets of a field are counted
+type BitNumbering int
+
+const (
+	// LSB0 numbers the least significant bit of the buffer as bit 0, the
+	// convention used everywhere else in this package
+	LSB0 BitNumbering = iota
+	// MSB0 numbers the most significant bit of the buffer as bit 0, as
+	// PowerPC manuals and many protocol diagrams do. The field offset is the
+	// position of its most significant bit.
+	MSB0
+)
+
+// lsb0Offset returns the LSB0 offset of the width bits field found at
+// bitOffset of a size bytes buffer, numbered as numbering says
+func lsb0Offset(op string, size int, bitOffset, width uint, numbering BitNumbering) (uint, error) {
+	if numbering != MSB0 {
+		return bitOffset, nil
+	}
+	if err := checkField(op, size, bitOffset, width); err != nil {
+		return 0, err
+	}
+	return uint(size)*8 - bitOffset - width, nil
+}
+
+func getBitsNumbered(b []byte, bitOffset, width uint, numbering BitNumbering, bigEndian bool) (uint64, error) {
+	lsbOffset, err := lsb0Offset("GetBits", len(b), bitOffset, width, numbering)
+	if err != nil {
+		return 0, err
+	}
+	return getBits(b, lsbOffset, width, bigEndian)
+}
+
+func setBitsNumbered(b []byte, bitOffset, width uint, v uint64, numbering BitNumbering, bigEndian bool) error {
+	lsbOffset, err := lsb0Offset("SetBits", len(b), bitOffset, width, numbering)
+	if err != nil {
+		return err
+	}
+	// Check the value here so errors report the offset as given
+	if _, err := fitValue("SetBits", bitOffset, width, v, OverflowError); err != nil {
+		return err
+	}
+	return setBits(b, lsbOffset, width, v, bigEndian)
+}
+
+// GetBitsNumbered returns the width bits field found at bitOffset of b, with
+// bitOffset counted as numbering says
+func (littleEndian) GetBitsNumbered(b []byte, bitOffset, width uint, numbering BitNumbering) (uint64, error) {
+	return getBitsNumbered(b, bitOffset, width, numbering, false)
+}
+
+// SetBitsNumbered overwrites the width bits field found at bitOffset of b
+// with v, with bitOffset counted as numbering says
+func (littleEndian) SetBitsNumbered(b []byte, bitOffset, width uint, v uint64, numbering BitNumbering) error {
+	return setBitsNumbered(b, bitOffset, width, v, numbering, false)
+}
+
+// GetBitsNumbered returns the width bits field found at bitOffset of b, with
+// bitOffset counted as numbering says
+func (bigEndian) GetBitsNumbered(b []byte, bitOffset, width uint, numbering BitNumbering) (uint64, error) {
+	return getBitsNumbered(b, bitOffset, width, numbering, true)
+}
+
+// SetBitsNumbered overwrites the width bits field found at bitOffset of b
+// with v, with bitOffset counted as numbering says
+func (bigEndian) SetBitsNumbered(b []byte, bitOffset, width uint, v uint64, numbering BitNumbering) error {
+	return setBitsNumbered(b, bitOffset, width, v, numbering, true)
+}
+
+// MakeMaskNumbered is MakeMaskOrder with offset counted as numbering says.
+// Like MakeMask, mask bits past the end of the buffer are dropped.
+func MakeMaskNumbered(order ByteOrder, size uint, width uint, offset uint, numbering BitNumbering) (outputMask []byte) {
+	if numbering == MSB0 {
+		sizeInBits := size * 8
+		if offset >= sizeInBits {
+			return make([]byte, size)
+		}
+		if width > sizeInBits-offset {
+			width = sizeInBits - offset
+		}
+		offset = sizeInBits - offset - width
+	}
+	return MakeMaskOrder(order, size, width, offset)
+}
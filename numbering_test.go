@@ -0,0 +1,82 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestBitsNumbered(t *testing.T) {
+	orders := []fieldOrder{bitwisebytes.LittleEndian, bitwisebytes.BigEndian}
+	getters := []func([]byte, uint, uint, bitwisebytes.BitNumbering) (uint64, error){bitwisebytes.LittleEndian.GetBitsNumbered, bitwisebytes.BigEndian.GetBitsNumbered}
+	setters := []func([]byte, uint, uint, uint64, bitwisebytes.BitNumbering) error{bitwisebytes.LittleEndian.SetBitsNumbered, bitwisebytes.BigEndian.SetBitsNumbered}
+
+	for o := range orders {
+		for i := 0; i < testLooops; i++ {
+			bytesSlice := make([]byte, 10)
+			rand.Read(bytesSlice)
+			width := uint(rand.Intn(64) + 1)
+			offset := uint(rand.Intn(80 - int(width) + 1))
+			v := rand.Uint64()
+			if width < 64 {
+				v &= 1<<width - 1
+			}
+
+			if err := setters[o](bytesSlice, offset, width, v, bitwisebytes.MSB0); err != nil {
+				t.Fatal(err.Error())
+			}
+			if r, err := getters[o](bytesSlice, offset, width, bitwisebytes.MSB0); err != nil || r != v {
+				t.Fatalf("width:%d offset:%d mistmatch: 0x%X != 0x%X %v", width, offset, r, v, err)
+			}
+			// MSB0 offset o is LSB0 offset size - o - width
+			if r, _ := orders[o].GetBits(bytesSlice, 80-offset-width, width); r != v {
+				t.Fatalf("width:%d offset:%d LSB0 mistmatch: 0x%X != 0x%X", width, offset, r, v)
+			}
+			if r, _ := getters[o](bytesSlice, 80-offset-width, width, bitwisebytes.LSB0); r != v {
+				t.Fatalf("width:%d offset:%d LSB0 numbered mistmatch: 0x%X != 0x%X", width, offset, r, v)
+			}
+		}
+	}
+
+	// PowerPC style: bits 0:3 of a big endian 32 bits register are its top nibble
+	if r, _ := bitwisebytes.BigEndian.GetBitsNumbered([]byte{0xA0, 0, 0, 0x01}, 0, 4, bitwisebytes.MSB0); r != 0xA {
+		t.Errorf("expected 0xA, got 0x%X", r)
+	}
+	if r, _ := bitwisebytes.BigEndian.GetBitsNumbered([]byte{0xA0, 0, 0, 0x01}, 31, 1, bitwisebytes.MSB0); r != 1 {
+		t.Errorf("expected 1, got 0x%X", r)
+	}
+
+	var fieldErr *bitwisebytes.FieldError
+	err := bitwisebytes.BigEndian.SetBitsNumbered(make([]byte, 2), 3, 4, 0x10, bitwisebytes.MSB0)
+	if !errors.Is(err, bitwisebytes.ErrValueOverflow) || !errors.As(err, &fieldErr) || fieldErr.Offset != 3 {
+		t.Errorf("expected ErrValueOverflow at offset 3, got %v", err)
+	}
+	if _, err := bitwisebytes.LittleEndian.GetBitsNumbered(make([]byte, 2), 14, 4, bitwisebytes.MSB0); !errors.Is(err, bitwisebytes.ErrBufferTooShort) {
+		t.Errorf("expected ErrBufferTooShort, got %v", err)
+	}
+}
+
+func TestMakeMaskNumbered(t *testing.T) {
+	cases := []struct {
+		order     bitwisebytes.ByteOrder
+		width     uint
+		offset    uint
+		numbering bitwisebytes.BitNumbering
+		expected  []byte
+	}{
+		{bitwisebytes.BigEndian, 4, 0, bitwisebytes.MSB0, []byte{0xF0, 0x00, 0x00}},
+		{bitwisebytes.BigEndian, 12, 8, bitwisebytes.MSB0, []byte{0x00, 0xFF, 0xF0}},
+		{bitwisebytes.LittleEndian, 12, 8, bitwisebytes.MSB0, []byte{0xF0, 0xFF, 0x00}},
+		{bitwisebytes.BigEndian, 12, 4, bitwisebytes.LSB0, []byte{0x00, 0xFF, 0xF0}},
+		{bitwisebytes.BigEndian, 12, 20, bitwisebytes.MSB0, []byte{0x00, 0x00, 0x0F}},
+		{bitwisebytes.BigEndian, 4, 24, bitwisebytes.MSB0, []byte{0x00, 0x00, 0x00}},
+	}
+	for _, c := range cases {
+		if r := bitwisebytes.MakeMaskNumbered(c.order, 3, c.width, c.offset, c.numbering); !bytes.Equal(r, c.expected) {
+			t.Errorf("width:%d offset:%d mistmatch: %X != %X", c.width, c.offset, r, c.expected)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package bitwisebytes
+
+// OverflowPolicy selects what an insert does with a value wider than its
+// field
+type OverflowPolicy int
+
+const (
+	// OverflowError rejects the value with ErrValueOverflow
+	OverflowError OverflowPolicy = iota
+	// OverflowTruncate keeps the width least significant bits of the value
+	OverflowTruncate
+	// OverflowSaturate writes the largest value the field can hold
+	OverflowSaturate
+)
+
+// fitValue returns v as it must be written in a width bits field
+func fitValue(op string, bitOffset, width uint, v uint64, policy OverflowPolicy) (uint64, error) {
+	if width == 0 || width > MaxFieldWidth {
+		return 0, fieldError(op, bitOffset, width, ErrWidthOutOfRange)
+	}
+	if width == 64 || v>>width == 0 {
+		return v, nil
+	}
+	switch policy {
+	case OverflowTruncate:
+		return v & (1<<width - 1), nil
+	case OverflowSaturate:
+		return 1<<width - 1, nil
+	}
+	return 0, fieldError(op, bitOffset, width, ErrValueOverflow)
+}
+
+func putShiftedBytes(op string, offset int, width uint, b []byte, v uint64, policy OverflowPolicy, bigEndian bool) error {
+	if offset < 0 || offset > 7 {
+		return shiftedOffsetError(op, offset, width)
+	}
+	v, err := fitValue(op, uint(offset), width, v, policy)
+	if err != nil {
+		return err
+	}
+	return setBits(b, uint(offset), width, v, bigEndian)
+}
+
+// SetBitsWithPolicy overwrites the width bits field found at bitOffset of b
+// with v, applying policy when v does not fit
+func (littleEndian) SetBitsWithPolicy(b []byte, bitOffset, width uint, v uint64, policy OverflowPolicy) error {
+	v, err := fitValue("SetBits", bitOffset, width, v, policy)
+	if err != nil {
+		return err
+	}
+	return setBits(b, bitOffset, width, v, false)
+}
+
+// PutUintShiftedBytes overwrites the width bits field found at offset of b
+// with v, applying policy when v does not fit. Unlike the PutUintNShiftedBytes
+// methods no bit outside of the field is ever modified.
+func (littleEndian) PutUintShiftedBytes(offset int, width uint, b []byte, v uint64, policy OverflowPolicy) error {
+	return putShiftedBytes("LittleEndian.PutUintShiftedBytes", offset, width, b, v, policy, false)
+}
+
+// SetBitsWithPolicy overwrites the width bits field found at bitOffset of b
+// with v, applying policy when v does not fit
+func (bigEndian) SetBitsWithPolicy(b []byte, bitOffset, width uint, v uint64, policy OverflowPolicy) error {
+	v, err := fitValue("SetBits", bitOffset, width, v, policy)
+	if err != nil {
+		return err
+	}
+	return setBits(b, bitOffset, width, v, true)
+}
+
+// PutUintShiftedBytes overwrites the width bits field found at offset of b
+// with v, applying policy when v does not fit. Unlike the PutUintNShiftedBytes
+// methods no bit outside of the field is ever modified.
+func (bigEndian) PutUintShiftedBytes(offset int, width uint, b []byte, v uint64, policy OverflowPolicy) error {
+	return putShiftedBytes("BigEndian.PutUintShiftedBytes", offset, width, b, v, policy, true)
+}
@@ -0,0 +1,76 @@
+package bitwisebytes_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+func TestPutUintShiftedBytesPolicy(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		randOffset := rand.Intn(8)
+		width := uint(rand.Intn(56) + 1)
+		fieldMax := uint64(1)<<width - 1
+
+		original := make([]byte, 9)
+		rand.Read(original)
+
+		// A value that fits is written as is
+		v := rand.Uint64() & fieldMax
+		destSlice := append([]byte(nil), original...)
+		if err := bitwisebytes.LittleEndian.PutUintShiftedBytes(randOffset, width, destSlice, v, bitwisebytes.OverflowError); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r, _ := bitwisebytes.LittleEndian.GetBits(destSlice, uint(randOffset), width); r != v {
+			t.Fatalf("mistmatch: 0x%X != 0x%X", r, v)
+		}
+
+		// A value that does not fit never touches the neighbors
+		wide := v | (fieldMax + 1)
+		destSlice = append([]byte(nil), original...)
+		err := bitwisebytes.BigEndian.PutUintShiftedBytes(randOffset, width, destSlice, wide, bitwisebytes.OverflowError)
+		if !errors.Is(err, bitwisebytes.ErrValueOverflow) || !bytes.Equal(destSlice, original) {
+			t.Fatalf("expected ErrValueOverflow and an untouched buffer, got %v %X", err, destSlice)
+		}
+
+		policies := map[bitwisebytes.OverflowPolicy]uint64{
+			bitwisebytes.OverflowTruncate: v,
+			bitwisebytes.OverflowSaturate: fieldMax,
+		}
+		for policy, expected := range policies {
+			destSlice = append([]byte(nil), original...)
+			if err := bitwisebytes.BigEndian.PutUintShiftedBytes(randOffset, width, destSlice, wide, policy); err != nil {
+				t.Fatal(err.Error())
+			}
+			if r, _ := bitwisebytes.BigEndian.GetBits(destSlice, uint(randOffset), width); r != expected {
+				t.Fatalf("policy %d mistmatch: 0x%X != 0x%X", policy, r, expected)
+			}
+			// Clear the field in both buffers, the rest must be equal
+			bitwisebytes.BigEndian.SetBits(destSlice, uint(randOffset), width, 0)
+			cleared := append([]byte(nil), original...)
+			bitwisebytes.BigEndian.SetBits(cleared, uint(randOffset), width, 0)
+			if !bytes.Equal(destSlice, cleared) {
+				t.Fatalf("policy %d modified bits outside of the field", policy)
+			}
+		}
+
+		if err := bitwisebytes.LittleEndian.SetBitsWithPolicy(destSlice, 8, width, wide, bitwisebytes.OverflowSaturate); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r, _ := bitwisebytes.LittleEndian.GetBits(destSlice, 8, width); r != fieldMax {
+			t.Fatalf("SetBitsWithPolicy mistmatch: 0x%X != 0x%X", r, fieldMax)
+		}
+	}
+
+	if err := bitwisebytes.LittleEndian.PutUintShiftedBytes(8, 4, make([]byte, 2), 1, bitwisebytes.OverflowTruncate); !errors.Is(err, bitwisebytes.ErrOffsetOutOfRange) {
+		t.Errorf("expected ErrOffsetOutOfRange, got %v", err)
+	}
+	var fieldErr *bitwisebytes.FieldError
+	err := bitwisebytes.BigEndian.PutUintShiftedBytes(-2, 4, make([]byte, 2), 1, bitwisebytes.OverflowTruncate)
+	if !errors.Is(err, bitwisebytes.ErrOffsetOutOfRange) || !errors.As(err, &fieldErr) || fieldErr.Offset != 0 {
+		t.Errorf("expected ErrOffsetOutOfRange at offset 0, got %v", err)
+	}
+}
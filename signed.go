@@ -0,0 +1,399 @@
+package bitwisebytes
+
+// ------------------------------------------------------------------
+//                   Signed (two's complement) fields
+// ------------------------------------------------------------------
+// Getters sign extend the field MSB into the returned value, setters
+// overwrite the field bits. The PutInt24/40/48/56 setters reject values
+// outside of the range the field can represent with ErrValueOverflow, the
+// full width PutIntN can not overflow and return nothing, like PutUintN.
+// Every PutIntNShiftedBytes setter returns an error, their buffer may lack
+// room for the top bits of the field.
+
+// signExtend interprets the width low bits of v as a two's complement value
+func signExtend(v uint64, width uint) int64 {
+	shift := 64 - width
+	return int64(v<<shift) >> shift
+}
+
+// signedFits reports whether v can be represented in width bits
+func signedFits(v int64, width uint) bool {
+	if width >= 64 {
+		return true
+	}
+	limit := int64(1) << (width - 1)
+	return v >= -limit && v < limit
+}
+
+// signedField returns the width bits two's complement encoding of v
+func signedField(op string, bitOffset, width uint, v int64) (uint64, error) {
+	if width == 0 || width > MaxFieldWidth {
+		return 0, fieldError(op, bitOffset, width, ErrWidthOutOfRange)
+	}
+	if !signedFits(v, width) {
+		return 0, fieldError(op, bitOffset, width, ErrValueOverflow)
+	}
+	if width == 64 {
+		return uint64(v), nil
+	}
+	return uint64(v) & (1<<width - 1), nil
+}
+
+// putSignedShifted overwrites the width bits field at offset of b with the
+// two's complement encoding of v. Like the Checked setters it returns a
+// *FieldError on a bad offset, a buffer of the wrong size or a value whose
+// bits would be shifted out of the buffer.
+func putSignedShifted[T Unsigned](order ByteOrder, op string, offset int, b []byte, width uint, v int64) error {
+	if offset < 0 || offset > 7 {
+		return shiftedOffsetError(op, offset, width)
+	}
+	field, err := signedField(op, uint(offset), width, v)
+	if err != nil {
+		return err
+	}
+	return shiftedPut(order, op, offset, b, width, T(field), true, true)
+}
+
+func getSignedBits(b []byte, bitOffset, width uint, bigEndian bool) (int64, error) {
+	v, err := getBits(b, bitOffset, width, bigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return signExtend(v, width), nil
+}
+
+func setSignedBits(b []byte, bitOffset, width uint, v int64, bigEndian bool) error {
+	field, err := signedField("SetSignedBits", bitOffset, width, v)
+	if err != nil {
+		return err
+	}
+	return setBits(b, bitOffset, width, field, bigEndian)
+}
+
+// GetSignedBits returns the sign extended width bits field found at
+// bitOffset of b
+func (littleEndian) GetSignedBits(b []byte, bitOffset, width uint) (int64, error) {
+	return getSignedBits(b, bitOffset, width, false)
+}
+
+// SetSignedBits overwrites the width bits field found at bitOffset of b with
+// the two's complement encoding of v
+func (littleEndian) SetSignedBits(b []byte, bitOffset, width uint, v int64) error {
+	return setSignedBits(b, bitOffset, width, v, false)
+}
+
+// GetSignedBits returns the sign extended width bits field found at
+// bitOffset of b
+func (bigEndian) GetSignedBits(b []byte, bitOffset, width uint) (int64, error) {
+	return getSignedBits(b, bitOffset, width, true)
+}
+
+// SetSignedBits overwrites the width bits field found at bitOffset of b with
+// the two's complement encoding of v
+func (bigEndian) SetSignedBits(b []byte, bitOffset, width uint, v int64) error {
+	return setSignedBits(b, bitOffset, width, v, true)
+}
+
+// -----------------------
+// Little Endian
+// -----------------------
+
+func (l littleEndian) Int8(b []byte) int8 {
+	return int8(signExtend(uint64(l.Uint8(b)), 8))
+}
+
+func (l littleEndian) PutInt8(b []byte, v int8) {
+	l.PutUint8(b, uint8(v))
+}
+
+func (l littleEndian) Int16(b []byte) int16 {
+	return int16(signExtend(uint64(l.Uint16(b)), 16))
+}
+
+func (l littleEndian) PutInt16(b []byte, v int16) {
+	l.PutUint16(b, uint16(v))
+}
+
+func (l littleEndian) Int24(b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint24(b)), 24))
+}
+
+func (l littleEndian) PutInt24(b []byte, v int32) error {
+	field, err := signedField("LittleEndian.PutInt24", 0, 24, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint24(b, uint32(field))
+	return nil
+}
+
+func (l littleEndian) Int32(b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint32(b)), 32))
+}
+
+func (l littleEndian) PutInt32(b []byte, v int32) {
+	l.PutUint32(b, uint32(v))
+}
+
+func (l littleEndian) Int40(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint40(b)), 40))
+}
+
+func (l littleEndian) PutInt40(b []byte, v int64) error {
+	field, err := signedField("LittleEndian.PutInt40", 0, 40, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint40(b, uint64(field))
+	return nil
+}
+
+func (l littleEndian) Int48(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint48(b)), 48))
+}
+
+func (l littleEndian) PutInt48(b []byte, v int64) error {
+	field, err := signedField("LittleEndian.PutInt48", 0, 48, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint48(b, uint64(field))
+	return nil
+}
+
+func (l littleEndian) Int56(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint56(b)), 56))
+}
+
+func (l littleEndian) PutInt56(b []byte, v int64) error {
+	field, err := signedField("LittleEndian.PutInt56", 0, 56, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint56(b, uint64(field))
+	return nil
+}
+
+func (l littleEndian) Int64(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint64(b)), 64))
+}
+
+func (l littleEndian) PutInt64(b []byte, v int64) {
+	l.PutUint64(b, uint64(v))
+}
+
+func (l littleEndian) Int8ShiftedBytes(offset int, b []byte) int8 {
+	return int8(signExtend(uint64(l.Uint8ShiftedBytes(-1, offset, b)), 8))
+}
+
+func (littleEndian) PutInt8ShiftedBytes(offset int, b []byte, v int8) error {
+	return putSignedShifted[uint8](LittleEndian, "LittleEndian.PutInt8ShiftedBytes", offset, b, 8, int64(v))
+}
+
+func (l littleEndian) Int16ShiftedBytes(offset int, b []byte) int16 {
+	return int16(signExtend(uint64(l.Uint16ShiftedBytes(-1, offset, b)), 16))
+}
+
+func (littleEndian) PutInt16ShiftedBytes(offset int, b []byte, v int16) error {
+	return putSignedShifted[uint16](LittleEndian, "LittleEndian.PutInt16ShiftedBytes", offset, b, 16, int64(v))
+}
+
+func (l littleEndian) Int24ShiftedBytes(offset int, b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint24ShiftedBytes(-1, offset, b)), 24))
+}
+
+func (littleEndian) PutInt24ShiftedBytes(offset int, b []byte, v int32) error {
+	return putSignedShifted[uint32](LittleEndian, "LittleEndian.PutInt24ShiftedBytes", offset, b, 24, int64(v))
+}
+
+func (l littleEndian) Int32ShiftedBytes(offset int, b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint32ShiftedBytes(-1, offset, b)), 32))
+}
+
+func (littleEndian) PutInt32ShiftedBytes(offset int, b []byte, v int32) error {
+	return putSignedShifted[uint32](LittleEndian, "LittleEndian.PutInt32ShiftedBytes", offset, b, 32, int64(v))
+}
+
+func (l littleEndian) Int40ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint40ShiftedBytes(-1, offset, b)), 40))
+}
+
+func (littleEndian) PutInt40ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](LittleEndian, "LittleEndian.PutInt40ShiftedBytes", offset, b, 40, int64(v))
+}
+
+func (l littleEndian) Int48ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint48ShiftedBytes(-1, offset, b)), 48))
+}
+
+func (littleEndian) PutInt48ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](LittleEndian, "LittleEndian.PutInt48ShiftedBytes", offset, b, 48, int64(v))
+}
+
+func (l littleEndian) Int56ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint56ShiftedBytes(-1, offset, b)), 56))
+}
+
+func (littleEndian) PutInt56ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](LittleEndian, "LittleEndian.PutInt56ShiftedBytes", offset, b, 56, int64(v))
+}
+
+func (l littleEndian) Int64ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint64ShiftedBytes(-1, offset, b)), 64))
+}
+
+func (littleEndian) PutInt64ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](LittleEndian, "LittleEndian.PutInt64ShiftedBytes", offset, b, 64, int64(v))
+}
+
+// -----------------------
+// Big Endian
+// -----------------------
+
+func (l bigEndian) Int8(b []byte) int8 {
+	return int8(signExtend(uint64(l.Uint8(b)), 8))
+}
+
+func (l bigEndian) PutInt8(b []byte, v int8) {
+	l.PutUint8(b, uint8(v))
+}
+
+func (l bigEndian) Int16(b []byte) int16 {
+	return int16(signExtend(uint64(l.Uint16(b)), 16))
+}
+
+func (l bigEndian) PutInt16(b []byte, v int16) {
+	l.PutUint16(b, uint16(v))
+}
+
+func (l bigEndian) Int24(b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint24(b)), 24))
+}
+
+func (l bigEndian) PutInt24(b []byte, v int32) error {
+	field, err := signedField("BigEndian.PutInt24", 0, 24, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint24(b, uint32(field))
+	return nil
+}
+
+func (l bigEndian) Int32(b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint32(b)), 32))
+}
+
+func (l bigEndian) PutInt32(b []byte, v int32) {
+	l.PutUint32(b, uint32(v))
+}
+
+func (l bigEndian) Int40(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint40(b)), 40))
+}
+
+func (l bigEndian) PutInt40(b []byte, v int64) error {
+	field, err := signedField("BigEndian.PutInt40", 0, 40, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint40(b, uint64(field))
+	return nil
+}
+
+func (l bigEndian) Int48(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint48(b)), 48))
+}
+
+func (l bigEndian) PutInt48(b []byte, v int64) error {
+	field, err := signedField("BigEndian.PutInt48", 0, 48, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint48(b, uint64(field))
+	return nil
+}
+
+func (l bigEndian) Int56(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint56(b)), 56))
+}
+
+func (l bigEndian) PutInt56(b []byte, v int64) error {
+	field, err := signedField("BigEndian.PutInt56", 0, 56, int64(v))
+	if err != nil {
+		return err
+	}
+	l.PutUint56(b, uint64(field))
+	return nil
+}
+
+func (l bigEndian) Int64(b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint64(b)), 64))
+}
+
+func (l bigEndian) PutInt64(b []byte, v int64) {
+	l.PutUint64(b, uint64(v))
+}
+
+func (l bigEndian) Int8ShiftedBytes(offset int, b []byte) int8 {
+	return int8(signExtend(uint64(l.Uint8ShiftedBytes(-1, offset, b)), 8))
+}
+
+func (bigEndian) PutInt8ShiftedBytes(offset int, b []byte, v int8) error {
+	return putSignedShifted[uint8](BigEndian, "BigEndian.PutInt8ShiftedBytes", offset, b, 8, int64(v))
+}
+
+func (l bigEndian) Int16ShiftedBytes(offset int, b []byte) int16 {
+	return int16(signExtend(uint64(l.Uint16ShiftedBytes(-1, offset, b)), 16))
+}
+
+func (bigEndian) PutInt16ShiftedBytes(offset int, b []byte, v int16) error {
+	return putSignedShifted[uint16](BigEndian, "BigEndian.PutInt16ShiftedBytes", offset, b, 16, int64(v))
+}
+
+func (l bigEndian) Int24ShiftedBytes(offset int, b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint24ShiftedBytes(-1, offset, b)), 24))
+}
+
+func (bigEndian) PutInt24ShiftedBytes(offset int, b []byte, v int32) error {
+	return putSignedShifted[uint32](BigEndian, "BigEndian.PutInt24ShiftedBytes", offset, b, 24, int64(v))
+}
+
+func (l bigEndian) Int32ShiftedBytes(offset int, b []byte) int32 {
+	return int32(signExtend(uint64(l.Uint32ShiftedBytes(-1, offset, b)), 32))
+}
+
+func (bigEndian) PutInt32ShiftedBytes(offset int, b []byte, v int32) error {
+	return putSignedShifted[uint32](BigEndian, "BigEndian.PutInt32ShiftedBytes", offset, b, 32, int64(v))
+}
+
+func (l bigEndian) Int40ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint40ShiftedBytes(-1, offset, b)), 40))
+}
+
+func (bigEndian) PutInt40ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](BigEndian, "BigEndian.PutInt40ShiftedBytes", offset, b, 40, int64(v))
+}
+
+func (l bigEndian) Int48ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint48ShiftedBytes(-1, offset, b)), 48))
+}
+
+func (bigEndian) PutInt48ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](BigEndian, "BigEndian.PutInt48ShiftedBytes", offset, b, 48, int64(v))
+}
+
+func (l bigEndian) Int56ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint56ShiftedBytes(-1, offset, b)), 56))
+}
+
+func (bigEndian) PutInt56ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](BigEndian, "BigEndian.PutInt56ShiftedBytes", offset, b, 56, int64(v))
+}
+
+func (l bigEndian) Int64ShiftedBytes(offset int, b []byte) int64 {
+	return int64(signExtend(uint64(l.Uint64ShiftedBytes(-1, offset, b)), 64))
+}
+
+func (bigEndian) PutInt64ShiftedBytes(offset int, b []byte, v int64) error {
+	return putSignedShifted[uint64](BigEndian, "BigEndian.PutInt64ShiftedBytes", offset, b, 64, int64(v))
+}
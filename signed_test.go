@@ -0,0 +1,125 @@
+package bitwisebytes_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/lagarciag/bitwisebytes"
+)
+
+// randSigned returns a random value that fits in width bits
+func randSigned(width uint) int64 {
+	v := int64(rand.Uint64())
+	return v << (64 - width) >> (64 - width)
+}
+
+func TestSignedBits(t *testing.T) {
+	orders := []fieldOrder{bitwisebytes.LittleEndian, bitwisebytes.BigEndian}
+	setters := []func([]byte, uint, uint, int64) error{bitwisebytes.LittleEndian.SetSignedBits, bitwisebytes.BigEndian.SetSignedBits}
+	getters := []func([]byte, uint, uint) (int64, error){bitwisebytes.LittleEndian.GetSignedBits, bitwisebytes.BigEndian.GetSignedBits}
+
+	for o := range orders {
+		for width := uint(1); width <= 64; width++ {
+			for i := 0; i < testLooops; i++ {
+				bytesSlice := make([]byte, 10)
+				rand.Read(bytesSlice)
+				offset := uint(rand.Intn(80 - int(width) + 1))
+				v := randSigned(width)
+
+				if err := setters[o](bytesSlice, offset, width, v); err != nil {
+					t.Fatal(err.Error())
+				}
+				r, err := getters[o](bytesSlice, offset, width)
+				if err != nil || r != v {
+					t.Fatalf("width:%d offset:%d mistmatch: %d != %d %v", width, offset, r, v, err)
+				}
+				raw, _ := orders[o].GetBits(bytesSlice, offset, width)
+				if (raw>>(width-1))&1 == 1 != (v < 0) {
+					t.Fatalf("width:%d sign bit of %d not set as expected", width, v)
+				}
+			}
+			if width < 64 {
+				tooBig := int64(1) << (width - 1)
+				if err := setters[o](make([]byte, 10), 0, width, tooBig); !errors.Is(err, bitwisebytes.ErrValueOverflow) {
+					t.Errorf("width:%d value %d must overflow, got %v", width, tooBig, err)
+				}
+				if err := setters[o](make([]byte, 10), 0, width, -tooBig-1); !errors.Is(err, bitwisebytes.ErrValueOverflow) {
+					t.Errorf("width:%d value %d must overflow, got %v", width, -tooBig-1, err)
+				}
+			}
+		}
+	}
+}
+
+func TestIntN(t *testing.T) {
+	for i := 0; i < testLooops; i++ {
+		v24 := int32(randSigned(24))
+		bytesSlice := make([]byte, 3)
+		if err := bitwisebytes.LittleEndian.PutInt24(bytesSlice, v24); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r := bitwisebytes.LittleEndian.Int24(bytesSlice); r != v24 {
+			t.Errorf("Int24 mistmatch: %d != %d", r, v24)
+		}
+		if err := bitwisebytes.BigEndian.PutInt24(bytesSlice, v24); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r := bitwisebytes.BigEndian.Int24(bytesSlice); r != v24 {
+			t.Errorf("BigEndian Int24 mistmatch: %d != %d", r, v24)
+		}
+
+		v16 := int16(randSigned(16))
+		bitwisebytes.LittleEndian.PutInt16(bytesSlice, v16)
+		if r := bitwisebytes.LittleEndian.Int16(bytesSlice); r != v16 {
+			t.Errorf("Int16 mistmatch: %d != %d", r, v16)
+		}
+
+		randOffset := rand.Intn(8)
+		v40 := randSigned(40)
+		shiftedSlice := make([]byte, 6)
+		rand.Read(shiftedSlice)
+		if err := bitwisebytes.LittleEndian.PutInt40ShiftedBytes(randOffset, shiftedSlice, v40); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r := bitwisebytes.LittleEndian.Int40ShiftedBytes(randOffset, shiftedSlice); r != v40 {
+			t.Errorf("Int40ShiftedBytes mistmatch: %d != %d offset:%d", r, v40, randOffset)
+		}
+		if err := bitwisebytes.BigEndian.PutInt40ShiftedBytes(randOffset, shiftedSlice, v40); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r := bitwisebytes.BigEndian.Int40ShiftedBytes(randOffset, shiftedSlice); r != v40 {
+			t.Errorf("BigEndian Int40ShiftedBytes mistmatch: %d != %d offset:%d", r, v40, randOffset)
+		}
+
+		v64 := int64(rand.Uint64())
+		shiftedSlice = make([]byte, 9)
+		if err := bitwisebytes.BigEndian.PutInt64ShiftedBytes(randOffset, shiftedSlice, v64); err != nil {
+			t.Fatal(err.Error())
+		}
+		if r := bitwisebytes.BigEndian.Int64ShiftedBytes(randOffset, shiftedSlice); r != v64 {
+			t.Errorf("Int64ShiftedBytes mistmatch: %d != %d offset:%d", r, v64, randOffset)
+		}
+	}
+
+	if err := bitwisebytes.LittleEndian.PutInt24(make([]byte, 3), 1<<23); !errors.Is(err, bitwisebytes.ErrValueOverflow) {
+		t.Errorf("expected ErrValueOverflow, got %v", err)
+	}
+	if r := bitwisebytes.BigEndian.Int24([]byte{0xFF, 0xFF, 0xFE}); r != -2 {
+		t.Errorf("expected -2, got %d", r)
+	}
+	if r := bitwisebytes.LittleEndian.Int16ShiftedBytes(4, []byte{0xF0, 0xFF, 0x0F}); r != -1 {
+		t.Errorf("expected -1, got %d", r)
+	}
+
+	// Without the extra byte the top bits of a full width field have no room
+	if err := bitwisebytes.LittleEndian.PutInt16ShiftedBytes(3, make([]byte, 2), -1); !errors.Is(err, bitwisebytes.ErrValueOverflow) {
+		t.Errorf("expected ErrValueOverflow, got %v", err)
+	}
+	// The offset is checked before the value
+	var fieldErr *bitwisebytes.FieldError
+	err := bitwisebytes.LittleEndian.PutInt24ShiftedBytes(-1, make([]byte, 4), 1<<30)
+	if !errors.Is(err, bitwisebytes.ErrOffsetOutOfRange) || !errors.As(err, &fieldErr) || fieldErr.Offset != 0 || fieldErr.Op != "LittleEndian.PutInt24ShiftedBytes" {
+		t.Errorf("expected a negative offset error, got %v", err)
+	}
+}